@@ -0,0 +1,167 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Flags of an ARP entry, see include/uapi/linux/if_arp.h.
+const (
+	// ARPFlagComplete is set once the hardware address is resolved.
+	ARPFlagComplete = 0x02
+	// ARPFlagPermanent is set for static entries.
+	ARPFlagPermanent = 0x04
+	// ARPFlagPublish is set for proxy ARP entries.
+	ARPFlagPublish = 0x08
+)
+
+// ARPEntry contains a single row of the columnar data represented in
+// /proc/net/arp.
+type ARPEntry struct {
+	// IP address
+	IPAddr net.IP
+	// Hardware type, e.g. 0x1 for Ethernet.
+	HWType uint16
+	// Entry flags, see the ARPFlag* constants.
+	Flags uint8
+	// MAC address
+	HWAddr net.HardwareAddr
+	// Mask, "*" unless the entry is a proxy ARP entry for a network.
+	Mask string
+	// Name of the device
+	Device string
+}
+
+// IsComplete reports whether the hardware address of the entry is resolved.
+func (e ARPEntry) IsComplete() bool {
+	return e.Flags&ARPFlagComplete != 0
+}
+
+// ARPEntries is a list of entries parsed from /proc/net/arp or
+// /proc/[pid]/net/arp.
+type ARPEntries []ARPEntry
+
+// ARPDeviceCount holds the number of ARP entries of a single device.
+type ARPDeviceCount struct {
+	// Total number of entries.
+	Entries int
+	// Number of entries without a resolved hardware address.
+	Incomplete int
+}
+
+// GatherARPEntries retrieves all the ARP entries, parses the relevant columns,
+// and then returns a list of ARPEntry's.
+func (fs FS) GatherARPEntries() (ARPEntries, error) {
+	return gatherARPEntries(fs.proc.Path("net/arp"))
+}
+
+// GatherARPEntries retrieves all the ARP entries of the network namespace of
+// the process from /proc/[pid]/net/arp.
+func (p Proc) GatherARPEntries() (ARPEntries, error) {
+	return gatherARPEntries(p.path("net/arp"))
+}
+
+func gatherARPEntries(file string) (ARPEntries, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading arp %s: %s", file, err)
+	}
+	defer f.Close()
+
+	return parseARPEntries(f)
+}
+
+func parseARPEntries(r io.Reader) (ARPEntries, error) {
+	var (
+		entries = ARPEntries{}
+		s       = bufio.NewScanner(r)
+	)
+
+	for n := 0; s.Scan(); n++ {
+		// Skip the header line.
+		if n == 0 {
+			continue
+		}
+
+		columns := strings.Fields(s.Text())
+		if len(columns) == 0 {
+			continue
+		}
+		if len(columns) != 6 {
+			return nil, fmt.Errorf("%d columns were detected, but 6 were expected", len(columns))
+		}
+
+		entry, err := parseARPEntry(columns)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.Err()
+}
+
+func parseARPEntry(columns []string) (ARPEntry, error) {
+	ip := net.ParseIP(columns[0])
+	if ip == nil {
+		return ARPEntry{}, fmt.Errorf("invalid IP address in arp entry: %s", columns[0])
+	}
+
+	hwType, err := strconv.ParseUint(strings.TrimPrefix(columns[1], "0x"), 16, 16)
+	if err != nil {
+		return ARPEntry{}, fmt.Errorf("couldn't parse %s (arp hw type): %s", columns[1], err)
+	}
+
+	flags, err := strconv.ParseUint(strings.TrimPrefix(columns[2], "0x"), 16, 8)
+	if err != nil {
+		return ARPEntry{}, fmt.Errorf("couldn't parse %s (arp flags): %s", columns[2], err)
+	}
+
+	mac, err := net.ParseMAC(columns[3])
+	if err != nil {
+		return ARPEntry{}, err
+	}
+
+	return ARPEntry{
+		IPAddr: ip,
+		HWType: uint16(hwType),
+		Flags:  uint8(flags),
+		HWAddr: mac,
+		Mask:   columns[4],
+		Device: columns[5],
+	}, nil
+}
+
+// DeviceCounts returns the number of entries per device. The map keys are
+// device names.
+func (entries ARPEntries) DeviceCounts() map[string]ARPDeviceCount {
+	counts := map[string]ARPDeviceCount{}
+	for _, e := range entries {
+		c := counts[e.Device]
+		c.Entries++
+		if !e.IsComplete() {
+			c.Incomplete++
+		}
+		counts[e.Device] = c
+	}
+
+	return counts
+}
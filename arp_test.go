@@ -0,0 +1,83 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestARP(t *testing.T) {
+	fs, err := NewFS(procTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := fs.GatherARPEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 4, len(entries); want != have {
+		t.Fatalf("want %d parsed arp entries, have %d", want, have)
+	}
+
+	mac, _ := net.ParseMAC("00:50:56:f5:41:f2")
+	want := ARPEntry{
+		IPAddr: net.ParseIP("192.168.224.2"),
+		HWType: 1,
+		Flags:  ARPFlagComplete | ARPFlagPermanent,
+		HWAddr: mac,
+		Mask:   "*",
+		Device: "ens33",
+	}
+	if have := entries[1]; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+
+	if entries[2].IsComplete() {
+		t.Errorf("want entry %s to be incomplete", entries[2].IPAddr)
+	}
+
+	counts := map[string]ARPDeviceCount{
+		"ens33": {Entries: 3, Incomplete: 1},
+		"eth1":  {Entries: 1},
+	}
+	if want, have := counts, entries.DeviceCounts(); !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestProcARP(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := p.GatherARPEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 1, len(entries); want != have {
+		t.Fatalf("want %d parsed arp entries, have %d", want, have)
+	}
+	if want, have := "172.17.0.1", entries[0].IPAddr.String(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "eth0", entries[0].Device; want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
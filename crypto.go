@@ -0,0 +1,160 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Crypto holds the information about a single algorithm of the kernel crypto
+// API, read from /proc/crypto. Fields that only apply to some algorithm types
+// are nil if they are not reported for the algorithm.
+type Crypto struct {
+	Name     string // Name of the algorithm, e.g. sha256.
+	Driver   string // Name of the implementation, e.g. sha256-generic.
+	Module   string // Module providing the implementation, or kernel if built in.
+	Priority int    // Implementations with a higher priority are preferred.
+	RefCnt   int    // Number of users of the implementation.
+	Selftest string // Result of the self test, passed or unknown.
+	Internal bool   // Whether the implementation is only for internal use.
+	Type     string // Algorithm type, e.g. cipher, shash or skcipher.
+
+	Async       *bool   // Whether the implementation is asynchronous.
+	BlockSize   *uint64 // Block size in bytes.
+	DigestSize  *uint64 // Digest size in bytes.
+	IVSize      *uint64 // Initialization vector size in bytes.
+	MinKeySize  *uint64 // Minimum key size in bytes.
+	MaxKeySize  *uint64 // Maximum key size in bytes.
+	MaxAuthSize *uint64 // Maximum authentication tag size in bytes (AEAD only).
+	SeedSize    *uint64 // Seed size in bytes (RNG only).
+	ChunkSize   *uint64 // Chunk size in bytes (skcipher only).
+	WalkSize    *uint64 // Walk size in bytes (skcipher only).
+	Geniv       string  // IV generator, if any.
+}
+
+// Crypto returns all algorithms registered with the kernel crypto API, read
+// from /proc/crypto.
+func (fs FS) Crypto() ([]Crypto, error) {
+	f, err := os.Open(fs.proc.Path("crypto"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseCrypto(f)
+}
+
+func parseCrypto(r io.Reader) ([]Crypto, error) {
+	var (
+		algorithms []Crypto
+		current    *Crypto
+		s          = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			// Records are separated by blank lines.
+			current = nil
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid crypto line, missing colon: %q", line)
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		if current == nil {
+			if key != "name" {
+				return nil, fmt.Errorf("crypto record starts with %q, expected name", key)
+			}
+			algorithms = append(algorithms, Crypto{})
+			current = &algorithms[len(algorithms)-1]
+		}
+
+		if err := current.fill(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return algorithms, s.Err()
+}
+
+func (c *Crypto) fill(key, value string) error {
+	var err error
+
+	switch key {
+	case "name":
+		c.Name = value
+	case "driver":
+		c.Driver = value
+	case "module":
+		c.Module = value
+	case "priority":
+		c.Priority, err = strconv.Atoi(value)
+	case "refcnt":
+		c.RefCnt, err = strconv.Atoi(value)
+	case "selftest":
+		c.Selftest = value
+	case "internal":
+		c.Internal = value == "yes"
+	case "type":
+		c.Type = value
+	case "async":
+		async := value == "yes"
+		c.Async = &async
+	case "blocksize":
+		c.BlockSize, err = parseCryptoSize(value)
+	case "digestsize":
+		c.DigestSize, err = parseCryptoSize(value)
+	case "ivsize":
+		c.IVSize, err = parseCryptoSize(value)
+	case "min keysize":
+		c.MinKeySize, err = parseCryptoSize(value)
+	case "max keysize":
+		c.MaxKeySize, err = parseCryptoSize(value)
+	case "maxauthsize":
+		c.MaxAuthSize, err = parseCryptoSize(value)
+	case "seedsize":
+		c.SeedSize, err = parseCryptoSize(value)
+	case "chunksize":
+		c.ChunkSize, err = parseCryptoSize(value)
+	case "walksize":
+		c.WalkSize, err = parseCryptoSize(value)
+	case "geniv":
+		c.Geniv = value
+	}
+
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s (crypto %s %s): %s", value, c.Name, key, err)
+	}
+
+	return nil
+}
+
+func parseCryptoSize(s string) (*uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
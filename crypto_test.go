@@ -0,0 +1,124 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCrypto(t *testing.T) {
+	algorithms, err := getProcFixtures(t).Crypto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		no   = false
+		size = func(v uint64) *uint64 { return &v }
+	)
+
+	want := []Crypto{
+		{
+			Name:        "ccm(aes)",
+			Driver:      "ccm_base(ctr(aes-aesni),cbcmac(aes-aesni))",
+			Module:      "ccm",
+			Priority:    300,
+			RefCnt:      4,
+			Selftest:    "passed",
+			Type:        "aead",
+			Async:       &no,
+			BlockSize:   size(1),
+			IVSize:      size(16),
+			MaxAuthSize: size(16),
+			Geniv:       "<none>",
+		},
+		{
+			Name:       "sha256",
+			Driver:     "sha256-generic",
+			Module:     "kernel",
+			Priority:   100,
+			RefCnt:     1,
+			Selftest:   "passed",
+			Type:       "shash",
+			BlockSize:  size(64),
+			DigestSize: size(32),
+		},
+		{
+			Name:       "__xts(aes)",
+			Driver:     "__xts-aes-aesni",
+			Module:     "aesni_intel",
+			Priority:   401,
+			RefCnt:     1,
+			Selftest:   "passed",
+			Internal:   true,
+			Type:       "skcipher",
+			Async:      &no,
+			BlockSize:  size(16),
+			MinKeySize: size(32),
+			MaxKeySize: size(64),
+			IVSize:     size(16),
+			ChunkSize:  size(16),
+			WalkSize:   size(16),
+		},
+		{
+			Name:     "stdrng",
+			Driver:   "drbg_nopr_hmac_sha256",
+			Module:   "kernel",
+			Priority: 221,
+			RefCnt:   2,
+			Selftest: "passed",
+			Type:     "rng",
+			SeedSize: size(0),
+		},
+	}
+
+	if want, have := len(want), len(algorithms); want != have {
+		t.Fatalf("want %d algorithms, have %d", want, have)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(want[i], algorithms[i]) {
+			t.Errorf("want %+v, have %+v", want[i], algorithms[i])
+		}
+	}
+}
+
+func TestParseCryptoErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{
+			name: "missing colon",
+			s:    "name : sha1\ndriver sha1-generic\n",
+		},
+		{
+			name: "record without name",
+			s:    "driver : sha1-generic\n",
+		},
+		{
+			name: "invalid size",
+			s:    "name : sha1\nblocksize : many\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCrypto(strings.NewReader(tt.s)); err == nil {
+				t.Error("want an error, have none")
+			}
+		})
+	}
+}
@@ -13,7 +13,13 @@
 
 package procfs
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
 
 const (
 	procTestFixtures = "fixtures/proc"
@@ -37,3 +43,41 @@ func getProcFixtures(t *testing.T) FS {
 	}
 	return fs
 }
+
+// getTempProc creates the process pid with the given files in a temporary
+// proc filesystem, for tests that write or delete files of a process. The
+// returned function removes the filesystem.
+func getTempProc(t *testing.T, pid int, files map[string]string) (Proc, func()) {
+	dir, err := ioutil.TempDir("", "procfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	writeProcDir(t, filepath.Join(dir, strconv.Itoa(pid)), files)
+
+	fs, err := NewFS(dir)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	p, err := fs.NewProc(pid)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	return p, cleanup
+}
+
+// writeProcDir creates the directory of a process with the given files.
+func writeProcDir(t *testing.T, dir string, files map[string]string) {
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -38,6 +38,62 @@ type IPVSStats struct {
 	IncomingBytes uint64
 	// Total outgoing traffic.
 	OutgoingBytes uint64
+	// Estimated current rates.
+	Rates IPVSRates
+}
+
+// IPVSRates holds the rates estimated by the kernel over the last couple of
+// seconds.
+type IPVSRates struct {
+	// Connections per second.
+	Connections uint64
+	// Incoming packets per second.
+	IncomingPackets uint64
+	// Outgoing packets per second.
+	OutgoingPackets uint64
+	// Incoming bytes per second.
+	IncomingBytes uint64
+	// Outgoing bytes per second.
+	OutgoingBytes uint64
+}
+
+// IPVSStatsPerCPU holds IPVS statistics, as exposed by the kernel in
+// `/proc/net/ip_vs_stats_percpu`.
+type IPVSStatsPerCPU struct {
+	// Summed up statistics, including the estimated rates.
+	Total IPVSStats
+	// Per-CPU statistics, rates are not estimated per CPU.
+	CPU []IPVSStats
+}
+
+// IPVSPersistence holds the persistence settings of a virtual service.
+type IPVSPersistence struct {
+	// Persistence timeout, in kernel jiffies as reported by the kernel.
+	Timeout uint64
+	// Netmask used to group clients, or the prefix length for IPv6 services.
+	Netmask uint32
+}
+
+// IPVSVirtualService holds the configuration of a virtual service and the
+// status of its real servers.
+type IPVSVirtualService struct {
+	// The transport protocol (TCP, UDP, SCTP), or FWM for firewall mark
+	// based services.
+	Proto string
+	// The virtual IP address, nil for firewall mark based services.
+	Address net.IP
+	// The virtual port, 0 for firewall mark based services.
+	Port uint16
+	// The firewall mark, 0 unless Proto is FWM.
+	FWMark uint32
+	// The name of the scheduler, e.g. wlc or rr.
+	Scheduler string
+	// Whether one-packet scheduling is enabled.
+	OnePacket bool
+	// The persistence settings, nil if the service is not persistent.
+	Persistence *IPVSPersistence
+	// The real servers of the service.
+	Backends []IPVSBackendStatus
 }
 
 // IPVSBackendStatus holds current metrics of one virtual / real address pair.
@@ -107,23 +163,116 @@ func parseIPVSStats(file io.Reader) (IPVSStats, error) {
 		return IPVSStats{}, errors.New("ip_vs_stats corrupt: unexpected number of fields")
 	}
 
-	stats.Connections, err = strconv.ParseUint(statFields[0], 16, 64)
+	stats, err = parseIPVSCounters(statFields)
 	if err != nil {
 		return IPVSStats{}, err
 	}
-	stats.IncomingPackets, err = strconv.ParseUint(statFields[1], 16, 64)
+
+	// The rates follow the totals after a blank line and another header.
+	rateLines := strings.Split(statLines[3], "\n")
+	if len(rateLines) > 2 {
+		stats.Rates, err = parseIPVSRates(strings.Fields(rateLines[2]))
+		if err != nil {
+			return IPVSStats{}, err
+		}
+	}
+
+	return stats, nil
+}
+
+// NewIPVSStatsPerCPU reads the per-CPU IPVS statistics from the specified
+// `proc` filesystem.
+func (fs FS) NewIPVSStatsPerCPU() (IPVSStatsPerCPU, error) {
+	file, err := os.Open(fs.proc.Path("net/ip_vs_stats_percpu"))
+	if err != nil {
+		return IPVSStatsPerCPU{}, err
+	}
+	defer file.Close()
+
+	return parseIPVSStatsPerCPU(file)
+}
+
+// parseIPVSStatsPerCPU performs the actual parsing of `ip_vs_stats_percpu`.
+func parseIPVSStatsPerCPU(file io.Reader) (IPVSStatsPerCPU, error) {
+	var (
+		stats   IPVSStatsPerCPU
+		scanner = bufio.NewScanner(file)
+		total   bool
+	)
+
+	for n := 0; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		// Skip the 2 header lines and the blank line before the rates.
+		if n < 2 || len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case fields[0] == "~":
+			if len(fields) != 6 {
+				return IPVSStatsPerCPU{}, errors.New("ip_vs_stats_percpu corrupt: unexpected number of fields")
+			}
+			counters, err := parseIPVSCounters(fields[1:])
+			if err != nil {
+				return IPVSStatsPerCPU{}, err
+			}
+			stats.Total = counters
+			total = true
+		case total:
+			// Once the totals are read, only the rates header and the
+			// rates are left.
+			if fields[0] == "Conns/s" {
+				continue
+			}
+			rates, err := parseIPVSRates(fields)
+			if err != nil {
+				return IPVSStatsPerCPU{}, err
+			}
+			stats.Total.Rates = rates
+		default:
+			if len(fields) != 6 {
+				return IPVSStatsPerCPU{}, errors.New("ip_vs_stats_percpu corrupt: unexpected number of fields")
+			}
+			counters, err := parseIPVSCounters(fields[1:])
+			if err != nil {
+				return IPVSStatsPerCPU{}, err
+			}
+			stats.CPU = append(stats.CPU, counters)
+		}
+	}
+
+	return stats, scanner.Err()
+}
+
+// parseIPVSCounters parses the five hexadecimal counters shared by
+// `ip_vs_stats` and `ip_vs_stats_percpu`.
+func parseIPVSCounters(fields []string) (IPVSStats, error) {
+	if len(fields) != 5 {
+		return IPVSStats{}, fmt.Errorf("unexpected number of IPVS counters: %d", len(fields))
+	}
+
+	var (
+		stats IPVSStats
+		err   error
+	)
+
+	stats.Connections, err = strconv.ParseUint(fields[0], 16, 64)
 	if err != nil {
 		return IPVSStats{}, err
 	}
-	stats.OutgoingPackets, err = strconv.ParseUint(statFields[2], 16, 64)
+	stats.IncomingPackets, err = strconv.ParseUint(fields[1], 16, 64)
 	if err != nil {
 		return IPVSStats{}, err
 	}
-	stats.IncomingBytes, err = strconv.ParseUint(statFields[3], 16, 64)
+	stats.OutgoingPackets, err = strconv.ParseUint(fields[2], 16, 64)
 	if err != nil {
 		return IPVSStats{}, err
 	}
-	stats.OutgoingBytes, err = strconv.ParseUint(statFields[4], 16, 64)
+	stats.IncomingBytes, err = strconv.ParseUint(fields[3], 16, 64)
+	if err != nil {
+		return IPVSStats{}, err
+	}
+	stats.OutgoingBytes, err = strconv.ParseUint(fields[4], 16, 64)
 	if err != nil {
 		return IPVSStats{}, err
 	}
@@ -131,6 +280,23 @@ func parseIPVSStats(file io.Reader) (IPVSStats, error) {
 	return stats, nil
 }
 
+// parseIPVSRates parses the five hexadecimal rates shared by `ip_vs_stats`
+// and `ip_vs_stats_percpu`.
+func parseIPVSRates(fields []string) (IPVSRates, error) {
+	counters, err := parseIPVSCounters(fields)
+	if err != nil {
+		return IPVSRates{}, err
+	}
+
+	return IPVSRates{
+		Connections:     counters.Connections,
+		IncomingPackets: counters.IncomingPackets,
+		OutgoingPackets: counters.OutgoingPackets,
+		IncomingBytes:   counters.IncomingBytes,
+		OutgoingBytes:   counters.OutgoingBytes,
+	}, nil
+}
+
 // NewIPVSBackendStatus reads and returns the status of all (virtual,real) server pairs.
 func NewIPVSBackendStatus() ([]IPVSBackendStatus, error) {
 	fs, err := NewFS(DefaultMountPoint)
@@ -153,14 +319,45 @@ func (fs FS) NewIPVSBackendStatus() ([]IPVSBackendStatus, error) {
 }
 
 func parseIPVSBackendStatus(file io.Reader) ([]IPVSBackendStatus, error) {
+	services, err := parseIPVSVirtualServices(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var status []IPVSBackendStatus
+	for _, service := range services {
+		status = append(status, service.Backends...)
+	}
+	return status, nil
+}
+
+// NewIPVSVirtualServices reads and returns all virtual services, with their
+// real servers grouped under them.
+func NewIPVSVirtualServices() ([]IPVSVirtualService, error) {
+	fs, err := NewFS(DefaultMountPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.NewIPVSVirtualServices()
+}
+
+// NewIPVSVirtualServices reads and returns all virtual services, with their
+// real servers grouped under them, from the specified `proc` filesystem.
+func (fs FS) NewIPVSVirtualServices() ([]IPVSVirtualService, error) {
+	file, err := os.Open(fs.proc.Path("net/ip_vs"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseIPVSVirtualServices(file)
+}
+
+func parseIPVSVirtualServices(file io.Reader) ([]IPVSVirtualService, error) {
 	var (
-		status       []IPVSBackendStatus
-		scanner      = bufio.NewScanner(file)
-		proto        string
-		localMark    string
-		localAddress net.IP
-		localPort    uint16
-		err          error
+		services []IPVSVirtualService
+		scanner  = bufio.NewScanner(file)
 	)
 
 	for scanner.Scan() {
@@ -169,30 +366,22 @@ func parseIPVSBackendStatus(file io.Reader) ([]IPVSBackendStatus, error) {
 			continue
 		}
 		switch {
-		case fields[0] == "IP" || fields[0] == "Prot" || fields[1] == "RemoteAddress:Port":
+		case fields[0] == "IP" || fields[0] == "Prot" || len(fields) > 1 && fields[1] == "RemoteAddress:Port":
 			continue
-		case fields[0] == "TCP" || fields[0] == "UDP":
+		case fields[0] == "TCP" || fields[0] == "UDP" || fields[0] == "SCTP" || fields[0] == "FWM":
 			if len(fields) < 2 {
 				continue
 			}
-			proto = fields[0]
-			localMark = ""
-			localAddress, localPort, err = parseIPPort(fields[1])
+			service, err := parseIPVSVirtualService(fields)
 			if err != nil {
 				return nil, err
 			}
-		case fields[0] == "FWM":
-			if len(fields) < 2 {
-				continue
-			}
-			proto = fields[0]
-			localMark = fields[1]
-			localAddress = nil
-			localPort = 0
+			services = append(services, service)
 		case fields[0] == "->":
-			if len(fields) < 6 {
+			if len(fields) < 6 || len(services) == 0 {
 				continue
 			}
+			service := &services[len(services)-1]
 			remoteAddress, remotePort, err := parseIPPort(fields[1])
 			if err != nil {
 				return nil, err
@@ -209,20 +398,73 @@ func parseIPVSBackendStatus(file io.Reader) ([]IPVSBackendStatus, error) {
 			if err != nil {
 				return nil, err
 			}
-			status = append(status, IPVSBackendStatus{
-				LocalAddress:  localAddress,
-				LocalPort:     localPort,
+			var localMark string
+			if service.Proto == "FWM" {
+				localMark = fmt.Sprintf("%08X", service.FWMark)
+			}
+			service.Backends = append(service.Backends, IPVSBackendStatus{
+				LocalAddress:  service.Address,
+				LocalPort:     service.Port,
 				LocalMark:     localMark,
 				RemoteAddress: remoteAddress,
 				RemotePort:    remotePort,
-				Proto:         proto,
+				Proto:         service.Proto,
 				Weight:        weight,
 				ActiveConn:    activeConn,
 				InactConn:     inactConn,
 			})
 		}
 	}
-	return status, nil
+	return services, scanner.Err()
+}
+
+// parseIPVSVirtualService parses a virtual service line of `ip_vs`, e.g.
+// "TCP  C0A80016:0CEA wlc ops persistent 300 FFFFFFFF".
+func parseIPVSVirtualService(fields []string) (IPVSVirtualService, error) {
+	var (
+		service = IPVSVirtualService{Proto: fields[0]}
+		err     error
+	)
+
+	if service.Proto == "FWM" {
+		mark, err := strconv.ParseUint(fields[1], 16, 32)
+		if err != nil {
+			return IPVSVirtualService{}, err
+		}
+		service.FWMark = uint32(mark)
+	} else {
+		service.Address, service.Port, err = parseIPPort(fields[1])
+		if err != nil {
+			return IPVSVirtualService{}, err
+		}
+	}
+
+	if len(fields) > 2 {
+		service.Scheduler = fields[2]
+	}
+
+	for i := 3; i < len(fields); i++ {
+		switch fields[i] {
+		case "ops":
+			service.OnePacket = true
+		case "persistent":
+			if i+2 >= len(fields) {
+				return IPVSVirtualService{}, fmt.Errorf("incomplete persistence flags: %s", strings.Join(fields, " "))
+			}
+			timeout, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return IPVSVirtualService{}, err
+			}
+			netmask, err := strconv.ParseUint(fields[i+2], 16, 32)
+			if err != nil {
+				return IPVSVirtualService{}, err
+			}
+			service.Persistence = &IPVSPersistence{Timeout: timeout, Netmask: uint32(netmask)}
+			i += 2
+		}
+	}
+
+	return service, nil
 }
 
 func parseIPPort(s string) (net.IP, uint16, error) {
@@ -15,6 +15,7 @@ package procfs
 
 import (
 	"net"
+	"reflect"
 	"testing"
 )
 
@@ -25,6 +26,13 @@ var (
 		OutgoingPackets: 0,
 		IncomingBytes:   89991519156915,
 		OutgoingBytes:   0,
+		Rates: IPVSRates{
+			Connections:     4,
+			IncomingPackets: 129852,
+			OutgoingPackets: 0,
+			IncomingBytes:   19409551,
+			OutgoingBytes:   0,
+		},
 	}
 	expectedIPVSBackendStatuses = []IPVSBackendStatus{
 		{
@@ -173,6 +181,97 @@ func TestIPVSStats(t *testing.T) {
 	}
 }
 
+func TestIPVSStatsPerCPU(t *testing.T) {
+	stats, err := getProcFixtures(t).NewIPVSStatsPerCPU()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Total != expectedIPVSStats {
+		t.Errorf("want %+v, have %+v", expectedIPVSStats, stats.Total)
+	}
+
+	want := []IPVSStats{
+		{
+			Connections:     741210,
+			IncomingPackets: 1906531778,
+			IncomingBytes:   48839650876688,
+		},
+		{
+			Connections:     23024662,
+			IncomingPackets: 1905457443,
+			IncomingBytes:   41151868280227,
+		},
+	}
+	if !reflect.DeepEqual(want, stats.CPU) {
+		t.Errorf("want %+v, have %+v", want, stats.CPU)
+	}
+}
+
+func TestIPVSVirtualServices(t *testing.T) {
+	services, err := getProcFixtures(t).NewIPVSVirtualServices()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 7, len(services); want != have {
+		t.Fatalf("want %d virtual services, have %d", want, have)
+	}
+
+	for i, want := range []struct {
+		proto       string
+		address     string
+		port        uint16
+		fwMark      uint32
+		scheduler   string
+		onePacket   bool
+		persistence *IPVSPersistence
+		backends    int
+	}{
+		{proto: "TCP", address: "192.168.0.22", port: 3306, scheduler: "wlc", backends: 3},
+		{proto: "TCP", address: "192.168.0.57", port: 3306, scheduler: "wlc", backends: 3},
+		{proto: "TCP", address: "192.168.0.55", port: 3306, scheduler: "wlc", backends: 2,
+			persistence: &IPVSPersistence{Timeout: 30000, Netmask: 0xffffffff}},
+		{proto: "TCP", address: "2620::1", port: 80, scheduler: "sh", backends: 3},
+		{proto: "TCP", address: "2620::5", port: 443, scheduler: "wrr",
+			persistence: &IPVSPersistence{Timeout: 360, Netmask: 64}},
+		{proto: "UDP", address: "192.168.0.22", port: 53, scheduler: "rr", onePacket: true},
+		{proto: "FWM", fwMark: 0x10001000, scheduler: "wlc", backends: 2,
+			persistence: &IPVSPersistence{Timeout: 60000, Netmask: 0xffffff00}},
+	} {
+		have := services[i]
+		if want.proto != have.Proto {
+			t.Errorf("%d: want Proto %s, have %s", i, want.proto, have.Proto)
+		}
+		if want.address != "" && !net.ParseIP(want.address).Equal(have.Address) || want.address == "" && have.Address != nil {
+			t.Errorf("%d: want Address %s, have %s", i, want.address, have.Address)
+		}
+		if want.port != have.Port {
+			t.Errorf("%d: want Port %d, have %d", i, want.port, have.Port)
+		}
+		if want.fwMark != have.FWMark {
+			t.Errorf("%d: want FWMark %#x, have %#x", i, want.fwMark, have.FWMark)
+		}
+		if want.scheduler != have.Scheduler {
+			t.Errorf("%d: want Scheduler %s, have %s", i, want.scheduler, have.Scheduler)
+		}
+		if want.onePacket != have.OnePacket {
+			t.Errorf("%d: want OnePacket %t, have %t", i, want.onePacket, have.OnePacket)
+		}
+		if !reflect.DeepEqual(want.persistence, have.Persistence) {
+			t.Errorf("%d: want Persistence %+v, have %+v", i, want.persistence, have.Persistence)
+		}
+		if want.backends != len(have.Backends) {
+			t.Errorf("%d: want %d backends, have %d", i, want.backends, len(have.Backends))
+		}
+		for _, backend := range have.Backends {
+			if backend.Proto != have.Proto || backend.LocalPort != have.Port {
+				t.Errorf("%d: backend %s:%d not grouped under its service", i, backend.RemoteAddress, backend.RemotePort)
+			}
+		}
+	}
+}
+
 func TestParseIPPort(t *testing.T) {
 	ip := net.ParseIP("192.168.0.22")
 	port := uint16(3306)
@@ -0,0 +1,84 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/procfs/internal/util"
+)
+
+// KernelRandom contains information about the kernel random number generator,
+// read from /proc/sys/kernel/random. Values that are not provided by the
+// running kernel are nil or empty, e.g. read_wakeup_threshold was removed
+// along with the blocking pool in Linux 5.6.
+type KernelRandom struct {
+	// Estimate of the entropy available in the input pool, in bits.
+	EntropyAvailable *uint64
+	// Size of the entropy pool, in bits.
+	PoolSize *uint64
+	// Number of bits of entropy below which readers of /dev/random wake up.
+	ReadWakeupThreshold *uint64
+	// Number of bits of entropy below which writers to /dev/random wake up.
+	WriteWakeupThreshold *uint64
+	// Minimum number of seconds between reseeds of the urandom pool. Recent
+	// kernels still provide it, but ignore its value.
+	URandomMinReseedSeconds *uint64
+	// Random UUID generated once per boot.
+	BootID string
+	// Random UUID, a new one is generated on every read.
+	UUID string
+}
+
+// KernelRandom returns values read from /proc/sys/kernel/random.
+func (fs FS) KernelRandom() (KernelRandom, error) {
+	path := fs.proc.Path("sys/kernel/random")
+
+	var random KernelRandom
+	for file, p := range map[string]**uint64{
+		"entropy_avail":           &random.EntropyAvailable,
+		"poolsize":                &random.PoolSize,
+		"read_wakeup_threshold":   &random.ReadWakeupThreshold,
+		"write_wakeup_threshold":  &random.WriteWakeupThreshold,
+		"urandom_min_reseed_secs": &random.URandomMinReseedSeconds,
+	} {
+		v, err := util.ReadUintFromFile(filepath.Join(path, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return KernelRandom{}, err
+		}
+		*p = &v
+	}
+
+	for file, p := range map[string]*string{
+		"boot_id": &random.BootID,
+		"uuid":    &random.UUID,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(path, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return KernelRandom{}, err
+		}
+		*p = strings.TrimSpace(string(data))
+	}
+
+	return random, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKernelRandom(t *testing.T) {
+	random, err := getProcFixtures(t).KernelRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := func(v uint64) *uint64 { return &v }
+	want := KernelRandom{
+		EntropyAvailable:        value(3943),
+		PoolSize:                value(4096),
+		WriteWakeupThreshold:    value(1024),
+		URandomMinReseedSeconds: value(60),
+		BootID:                  "6cd17fc6-57b0-4f4b-bd4d-9e7c1f3a1c2e",
+		UUID:                    "2e15f1a2-1d0b-4b5f-9a7e-3c1e8f0d2b64",
+	}
+
+	// read_wakeup_threshold is missing from the fixtures, like on newer kernels.
+	if !reflect.DeepEqual(want, random) {
+		t.Errorf("want %+v, have %+v", want, random)
+	}
+}
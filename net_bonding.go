@@ -0,0 +1,263 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NetBondingAggregator holds the active aggregator of an 802.3ad bond.
+type NetBondingAggregator struct {
+	// The aggregator ID.
+	ID uint64
+	// Number of ports in the aggregator.
+	NumberOfPorts uint64
+	// LACP key of the local system.
+	ActorKey uint64
+	// LACP key of the link partner.
+	PartnerKey uint64
+	// MAC address of the link partner.
+	PartnerMACAddress string
+}
+
+// NetBondingSlave holds the status of a single slave interface of a bond.
+type NetBondingSlave struct {
+	// The name of the slave interface.
+	Name string
+	// The link status as monitored by MII, "up" or "down".
+	MIIStatus string
+	// Link speed in Mbps, nil if unknown.
+	Speed *uint64
+	// Duplex mode, "full", "half" or "unknown".
+	Duplex string
+	// Number of times the link of the slave failed.
+	LinkFailureCount uint64
+	// The permanent MAC address of the slave.
+	PermanentHWAddr string
+	// The transmit queue ID of the slave.
+	QueueID uint64
+	// The ID of the aggregator the slave belongs to, 0 unless the bond is in
+	// 802.3ad mode.
+	AggregatorID uint64
+}
+
+// NetBonding holds the status of a bonding interface, read from
+// /proc/net/bonding/<bond>.
+type NetBonding struct {
+	// The name of the bonding interface.
+	Name string
+	// The bonding mode, e.g. "fault-tolerance (active-backup)".
+	Mode string
+	// The transmit hash policy, e.g. "layer3+4 (1)". Only reported for
+	// modes that balance traffic by hash.
+	TransmitHashPolicy string
+	// The primary slave, if configured.
+	PrimarySlave string
+	// The currently active slave. Only reported for modes with a single
+	// active slave, e.g. active-backup.
+	ActiveSlave string
+	// The link status of the bond as monitored by MII, "up" or "down".
+	MIIStatus string
+	// MII link monitoring interval in milliseconds.
+	MIIPollingInterval uint64
+	// Delay in milliseconds before enabling a slave after link recovery.
+	UpDelay uint64
+	// Delay in milliseconds before disabling a slave after link failure.
+	DownDelay uint64
+	// The LACP rate, "slow" or "fast". Only reported in 802.3ad mode.
+	LACPRate string
+	// The active aggregator, nil unless the bond is in 802.3ad mode.
+	ActiveAggregator *NetBondingAggregator
+	// The slave interfaces of the bond.
+	Slaves []NetBondingSlave
+}
+
+// NetBondingInterfaces returns the names of all bonding interfaces found in
+// /proc/net/bonding.
+func (fs FS) NetBondingInterfaces() ([]string, error) {
+	d, err := os.Open(fs.proc.Path("net/bonding"))
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %s", d.Name(), err)
+	}
+
+	return names, nil
+}
+
+// NetBonding returns the status of the given bonding interface read from
+// /proc/net/bonding/<bond>.
+func (fs FS) NetBonding(bond string) (NetBonding, error) {
+	f, err := os.Open(fs.proc.Path("net/bonding", bond))
+	if err != nil {
+		return NetBonding{}, err
+	}
+	defer f.Close()
+
+	return parseNetBonding(bond, f)
+}
+
+func parseNetBonding(name string, r io.Reader) (NetBonding, error) {
+	var (
+		bond    = NetBonding{Name: name}
+		slave   *NetBondingSlave
+		section string
+		s       = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			section = ""
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			// Section titles like "802.3ad info" have no value.
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		// Indented lines belong to the section opened by the last
+		// unindented line without a value.
+		if line[0] == ' ' || line[0] == '\t' {
+			if section == "Active Aggregator Info" {
+				if err := bond.ActiveAggregator.set(key, value); err != nil {
+					return NetBonding{}, err
+				}
+			}
+			continue
+		}
+		if value == "" {
+			section = key
+			if section == "Active Aggregator Info" {
+				bond.ActiveAggregator = &NetBondingAggregator{}
+			}
+			continue
+		}
+		section = ""
+
+		if key == "Slave Interface" {
+			bond.Slaves = append(bond.Slaves, NetBondingSlave{Name: value})
+			slave = &bond.Slaves[len(bond.Slaves)-1]
+			continue
+		}
+
+		var err error
+		if slave != nil {
+			err = slave.set(key, value)
+		} else {
+			err = bond.set(key, value)
+		}
+		if err != nil {
+			return NetBonding{}, err
+		}
+	}
+
+	return bond, s.Err()
+}
+
+func (b *NetBonding) set(key, value string) error {
+	var err error
+
+	switch key {
+	case "Bonding Mode":
+		b.Mode = value
+	case "Transmit Hash Policy":
+		b.TransmitHashPolicy = value
+	case "Primary Slave":
+		b.PrimarySlave = value
+	case "Currently Active Slave":
+		b.ActiveSlave = value
+	case "MII Status":
+		b.MIIStatus = value
+	case "MII Polling Interval (ms)":
+		b.MIIPollingInterval, err = strconv.ParseUint(value, 10, 64)
+	case "Up Delay (ms)":
+		b.UpDelay, err = strconv.ParseUint(value, 10, 64)
+	case "Down Delay (ms)":
+		b.DownDelay, err = strconv.ParseUint(value, 10, 64)
+	case "LACP rate":
+		b.LACPRate = value
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s (bonding %s): %s", value, key, err)
+	}
+
+	return nil
+}
+
+func (a *NetBondingAggregator) set(key, value string) error {
+	var err error
+
+	switch key {
+	case "Aggregator ID":
+		a.ID, err = strconv.ParseUint(value, 10, 64)
+	case "Number of ports":
+		a.NumberOfPorts, err = strconv.ParseUint(value, 10, 64)
+	case "Actor Key":
+		a.ActorKey, err = strconv.ParseUint(value, 10, 64)
+	case "Partner Key":
+		a.PartnerKey, err = strconv.ParseUint(value, 10, 64)
+	case "Partner Mac Address":
+		a.PartnerMACAddress = value
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s (bonding aggregator %s): %s", value, key, err)
+	}
+
+	return nil
+}
+
+func (sl *NetBondingSlave) set(key, value string) error {
+	var err error
+
+	switch key {
+	case "MII Status":
+		sl.MIIStatus = value
+	case "Speed":
+		// Either "<n> Mbps" or "Unknown".
+		if fields := strings.Fields(value); len(fields) == 2 && fields[1] == "Mbps" {
+			var speed uint64
+			speed, err = strconv.ParseUint(fields[0], 10, 64)
+			sl.Speed = &speed
+		}
+	case "Duplex":
+		sl.Duplex = value
+	case "Link Failure Count":
+		sl.LinkFailureCount, err = strconv.ParseUint(value, 10, 64)
+	case "Permanent HW addr":
+		sl.PermanentHWAddr = value
+	case "Slave queue ID":
+		sl.QueueID, err = strconv.ParseUint(value, 10, 64)
+	case "Aggregator ID":
+		sl.AggregatorID, err = strconv.ParseUint(value, 10, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s (bonding slave %s): %s", value, key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNetBondingInterfaces(t *testing.T) {
+	bonds, err := getProcFixtures(t).NetBondingInterfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(bonds)
+
+	if want, have := []string{"bond0", "bond1"}, bonds; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestNetBonding8023ad(t *testing.T) {
+	bond, err := getProcFixtures(t).NetBonding("bond0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	speed := uint64(10000)
+	want := NetBonding{
+		Name:               "bond0",
+		Mode:               "IEEE 802.3ad Dynamic link aggregation",
+		TransmitHashPolicy: "layer3+4 (1)",
+		MIIStatus:          "up",
+		MIIPollingInterval: 100,
+		UpDelay:            200,
+		DownDelay:          200,
+		LACPRate:           "fast",
+		ActiveAggregator: &NetBondingAggregator{
+			ID:                1,
+			NumberOfPorts:     2,
+			ActorKey:          15,
+			PartnerKey:        32773,
+			PartnerMACAddress: "00:1c:73:aa:bb:cc",
+		},
+		Slaves: []NetBondingSlave{
+			{
+				Name:             "eth0",
+				MIIStatus:        "up",
+				Speed:            &speed,
+				Duplex:           "full",
+				LinkFailureCount: 1,
+				PermanentHWAddr:  "52:54:00:a1:b2:c3",
+				AggregatorID:     1,
+			},
+			{
+				Name:             "eth1",
+				MIIStatus:        "down",
+				Duplex:           "Unknown",
+				LinkFailureCount: 4,
+				PermanentHWAddr:  "52:54:00:a1:b2:c4",
+				AggregatorID:     2,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(want, bond) {
+		t.Errorf("want %+v, have %+v", want, bond)
+	}
+}
+
+func TestNetBondingActiveBackup(t *testing.T) {
+	bond, err := getProcFixtures(t).NetBonding("bond1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	speed := uint64(1000)
+	want := NetBonding{
+		Name:               "bond1",
+		Mode:               "fault-tolerance (active-backup)",
+		PrimarySlave:       "None",
+		ActiveSlave:        "eth3",
+		MIIStatus:          "up",
+		MIIPollingInterval: 100,
+		Slaves: []NetBondingSlave{
+			{
+				Name:             "eth2",
+				MIIStatus:        "down",
+				Duplex:           "Unknown",
+				LinkFailureCount: 2,
+				PermanentHWAddr:  "52:54:00:d4:e5:f6",
+			},
+			{
+				Name:            "eth3",
+				MIIStatus:       "up",
+				Speed:           &speed,
+				Duplex:          "full",
+				PermanentHWAddr: "52:54:00:d4:e5:f7",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(want, bond) {
+		t.Errorf("want %+v, have %+v", want, bond)
+	}
+}
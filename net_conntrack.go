@@ -0,0 +1,163 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/procfs/internal/util"
+)
+
+// ConntrackUsage holds the size of the connection tracking table, read from
+// /proc/sys/net/netfilter.
+type ConntrackUsage struct {
+	// Number of entries currently in the table.
+	Count uint64
+	// Maximum number of entries of the table.
+	Max uint64
+}
+
+// ConntrackStatEntry holds the connection tracking statistics of a single CPU,
+// or the sum over all CPUs.
+type ConntrackStatEntry struct {
+	// Number of entries in the table. The kernel reports the table-wide count
+	// on every row, so this is not summed up.
+	Entries uint64
+	// Number of successful searches.
+	Found uint64
+	// Number of packets that could not be tracked.
+	Invalid uint64
+	// Number of packets that were already tracked or not trackable.
+	Ignore uint64
+	// Number of inserted entries.
+	Insert uint64
+	// Number of entries that could not be inserted, e.g. due to races.
+	InsertFailed uint64
+	// Number of packets dropped because a new entry could not be created.
+	Drop uint64
+	// Number of entries dropped to make room for new ones when the table
+	// was full.
+	EarlyDrop uint64
+	// Number of lookups restarted due to hash table resizing.
+	SearchRestart uint64
+}
+
+// ConntrackStat holds the statistics of /proc/net/stat/nf_conntrack.
+type ConntrackStat struct {
+	// Summed up statistics.
+	Total ConntrackStatEntry
+	// Per-CPU statistics.
+	CPU []ConntrackStatEntry
+}
+
+// ConntrackUsage reads the number of entries and the maximum size of the
+// connection tracking table.
+func (fs FS) ConntrackUsage() (ConntrackUsage, error) {
+	count, err := util.ReadUintFromFile(fs.proc.Path("sys/net/netfilter/nf_conntrack_count"))
+	if err != nil {
+		return ConntrackUsage{}, err
+	}
+	max, err := util.ReadUintFromFile(fs.proc.Path("sys/net/netfilter/nf_conntrack_max"))
+	if err != nil {
+		return ConntrackUsage{}, err
+	}
+
+	return ConntrackUsage{Count: count, Max: max}, nil
+}
+
+// ConntrackStat reads the per-CPU connection tracking statistics from
+// /proc/net/stat/nf_conntrack.
+func (fs FS) ConntrackStat() (ConntrackStat, error) {
+	f, err := os.Open(fs.proc.Path("net/stat/nf_conntrack"))
+	if err != nil {
+		return ConntrackStat{}, err
+	}
+	defer f.Close()
+
+	return parseConntrackStat(f)
+}
+
+func parseConntrackStat(r io.Reader) (ConntrackStat, error) {
+	var (
+		stat   ConntrackStat
+		header []string
+		s      = bufio.NewScanner(r)
+	)
+
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return ConntrackStat{}, err
+		}
+		return ConntrackStat{}, errors.New("nf_conntrack corrupt: missing header")
+	}
+	header = strings.Fields(s.Text())
+
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != len(header) {
+			return ConntrackStat{}, fmt.Errorf("%d columns were detected, but %d were expected", len(fields), len(header))
+		}
+
+		var entry ConntrackStatEntry
+		for i, name := range header {
+			v, err := strconv.ParseUint(fields[i], 16, 64)
+			if err != nil {
+				return ConntrackStat{}, fmt.Errorf("couldn't parse %s (nf_conntrack %s): %s", fields[i], name, err)
+			}
+
+			switch name {
+			case "entries":
+				entry.Entries = v
+			case "found":
+				entry.Found = v
+			case "invalid":
+				entry.Invalid = v
+			case "ignore":
+				entry.Ignore = v
+			case "insert":
+				entry.Insert = v
+			case "insert_failed":
+				entry.InsertFailed = v
+			case "drop":
+				entry.Drop = v
+			case "early_drop":
+				entry.EarlyDrop = v
+			case "search_restart":
+				entry.SearchRestart = v
+			}
+		}
+
+		stat.Total.Entries = entry.Entries
+		stat.Total.Found += entry.Found
+		stat.Total.Invalid += entry.Invalid
+		stat.Total.Ignore += entry.Ignore
+		stat.Total.Insert += entry.Insert
+		stat.Total.InsertFailed += entry.InsertFailed
+		stat.Total.Drop += entry.Drop
+		stat.Total.EarlyDrop += entry.EarlyDrop
+		stat.Total.SearchRestart += entry.SearchRestart
+		stat.CPU = append(stat.CPU, entry)
+	}
+
+	return stat, s.Err()
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConntrackUsage(t *testing.T) {
+	u, err := getProcFixtures(t).ConntrackUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := (ConntrackUsage{Count: 39, Max: 262144}), u; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestConntrackStat(t *testing.T) {
+	stat, err := getProcFixtures(t).ConntrackStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ConntrackStat{
+		Total: ConntrackStatEntry{
+			Entries:       33,
+			Found:         10,
+			Invalid:       5,
+			Ignore:        44846,
+			Insert:        1,
+			InsertFailed:  2,
+			Drop:          5,
+			EarlyDrop:     6,
+			SearchRestart: 5,
+		},
+		CPU: []ConntrackStatEntry{
+			{
+				Entries:       33,
+				Invalid:       3,
+				Ignore:        22666,
+				SearchRestart: 4,
+			},
+			{
+				Entries:       33,
+				Found:         10,
+				Invalid:       2,
+				Ignore:        22180,
+				Insert:        1,
+				InsertFailed:  2,
+				Drop:          5,
+				EarlyDrop:     6,
+				SearchRestart: 1,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(want, stat) {
+		t.Errorf("want %+v, have %+v", want, stat)
+	}
+}
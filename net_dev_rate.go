@@ -0,0 +1,107 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// NetDevRateLine holds the per-second rates of a single interface, computed
+// from two NetDevLine samples.
+type NetDevRateLine struct {
+	Name         string  `json:"name"`          // The name of the interface.
+	RxBytes      float64 `json:"rx_bytes"`      // Bytes received per second.
+	RxPackets    float64 `json:"rx_packets"`    // Packets received per second.
+	RxErrors     float64 `json:"rx_errors"`     // Receive errors per second.
+	RxDropped    float64 `json:"rx_dropped"`    // Packets dropped while receiving per second.
+	RxFIFO       float64 `json:"rx_fifo"`       // Receive FIFO buffer errors per second.
+	RxFrame      float64 `json:"rx_frame"`      // Packet framing errors per second.
+	RxCompressed float64 `json:"rx_compressed"` // Compressed packets received per second.
+	RxMulticast  float64 `json:"rx_multicast"`  // Multicast frames received per second.
+	TxBytes      float64 `json:"tx_bytes"`      // Bytes transmitted per second.
+	TxPackets    float64 `json:"tx_packets"`    // Packets transmitted per second.
+	TxErrors     float64 `json:"tx_errors"`     // Transmit errors per second.
+	TxDropped    float64 `json:"tx_dropped"`    // Packets dropped while transmitting per second.
+	TxFIFO       float64 `json:"tx_fifo"`       // Transmit FIFO buffer errors per second.
+	TxCollisions float64 `json:"tx_collisions"` // Collisions per second.
+	TxCarrier    float64 `json:"tx_carrier"`    // Carrier losses per second.
+	TxCompressed float64 `json:"tx_compressed"` // Compressed packets transmitted per second.
+}
+
+// NetDevRates holds the rates of all interfaces present in both samples. The
+// map keys are interface names.
+type NetDevRates map[string]NetDevRateLine
+
+// Rates computes the per-second rates between an earlier sample prev and nd,
+// taken interval apart.
+//
+// Interfaces that are only present in one of the samples, because they
+// appeared or disappeared in between, are left out. A counter that went
+// backwards is assumed to have wrapped around if its previous value was in
+// the upper half of the 32 bit range, as happens with drivers still using 32
+// bit counters, and to have been reset to zero otherwise.
+func (nd NetDev) Rates(prev NetDev, interval time.Duration) (NetDevRates, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval between net/dev samples: %s", interval)
+	}
+
+	seconds := interval.Seconds()
+	rate := func(prev, cur uint64) float64 {
+		return float64(counterDelta(prev, cur)) / seconds
+	}
+
+	rates := NetDevRates{}
+	for name, cur := range nd {
+		p, ok := prev[name]
+		if !ok {
+			continue
+		}
+
+		rates[name] = NetDevRateLine{
+			Name:         name,
+			RxBytes:      rate(p.RxBytes, cur.RxBytes),
+			RxPackets:    rate(p.RxPackets, cur.RxPackets),
+			RxErrors:     rate(p.RxErrors, cur.RxErrors),
+			RxDropped:    rate(p.RxDropped, cur.RxDropped),
+			RxFIFO:       rate(p.RxFIFO, cur.RxFIFO),
+			RxFrame:      rate(p.RxFrame, cur.RxFrame),
+			RxCompressed: rate(p.RxCompressed, cur.RxCompressed),
+			RxMulticast:  rate(p.RxMulticast, cur.RxMulticast),
+			TxBytes:      rate(p.TxBytes, cur.TxBytes),
+			TxPackets:    rate(p.TxPackets, cur.TxPackets),
+			TxErrors:     rate(p.TxErrors, cur.TxErrors),
+			TxDropped:    rate(p.TxDropped, cur.TxDropped),
+			TxFIFO:       rate(p.TxFIFO, cur.TxFIFO),
+			TxCollisions: rate(p.TxCollisions, cur.TxCollisions),
+			TxCarrier:    rate(p.TxCarrier, cur.TxCarrier),
+			TxCompressed: rate(p.TxCompressed, cur.TxCompressed),
+		}
+	}
+
+	return rates, nil
+}
+
+// counterDelta returns the increase of a counter between two samples,
+// accounting for 32 bit wraparounds and counter resets.
+func counterDelta(prev, cur uint64) uint64 {
+	if cur >= prev {
+		return cur - prev
+	}
+	if prev <= math.MaxUint32 && prev > math.MaxUint32/2 && cur <= math.MaxUint32 {
+		return cur + (math.MaxUint32 - prev) + 1
+	}
+	return cur
+}
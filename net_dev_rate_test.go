@@ -0,0 +1,61 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNetDevRates(t *testing.T) {
+	prev := NetDev{
+		"eth0": {Name: "eth0", RxBytes: 1000, RxPackets: 10, TxBytes: 2000, TxPackets: 20, RxErrors: 1},
+		"eth1": {Name: "eth1", RxBytes: math.MaxUint32 - 99, TxBytes: 5000},
+		"eth2": {Name: "eth2", RxBytes: 1 << 40},
+		"gone": {Name: "gone", RxBytes: 100},
+	}
+	cur := NetDev{
+		"eth0": {Name: "eth0", RxBytes: 3000, RxPackets: 30, TxBytes: 2000, TxPackets: 24, RxErrors: 3},
+		// RxBytes wrapped around a 32 bit counter, TxBytes was reset.
+		"eth1": {Name: "eth1", RxBytes: 100, TxBytes: 400},
+		// A 64 bit counter going backwards was reset.
+		"eth2": {Name: "eth2", RxBytes: 1000},
+		"new":  {Name: "new", RxBytes: 100},
+	}
+
+	rates, err := cur.Rates(prev, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := NetDevRates{
+		"eth0": {Name: "eth0", RxBytes: 1000, RxPackets: 10, TxPackets: 2, RxErrors: 1},
+		"eth1": {Name: "eth1", RxBytes: 100, TxBytes: 200},
+		"eth2": {Name: "eth2", RxBytes: 500},
+	}
+
+	if want, have := len(want), len(rates); want != have {
+		t.Errorf("want %d interface rates, have %d", want, have)
+	}
+	for name, w := range want {
+		if have := rates[name]; w != have {
+			t.Errorf("%s: want %+v, have %+v", name, w, have)
+		}
+	}
+
+	if _, err := cur.Rates(prev, 0); err == nil {
+		t.Error("want an error for a zero interval")
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NetDevSNMP6Ip holds the IPv6 counters of an interface, the lines starting
+// with Ip6 in /proc/net/dev_snmp6/<iface>.
+type NetDevSNMP6Ip struct {
+	InReceives       uint64
+	InHdrErrors      uint64
+	InTooBigErrors   uint64
+	InNoRoutes       uint64
+	InAddrErrors     uint64
+	InUnknownProtos  uint64
+	InTruncatedPkts  uint64
+	InDiscards       uint64
+	InDelivers       uint64
+	OutForwDatagrams uint64
+	OutRequests      uint64
+	OutDiscards      uint64
+	OutNoRoutes      uint64
+	ReasmTimeout     uint64
+	ReasmReqds       uint64
+	ReasmOKs         uint64
+	ReasmFails       uint64
+	FragOKs          uint64
+	FragFails        uint64
+	FragCreates      uint64
+	InMcastPkts      uint64
+	OutMcastPkts     uint64
+	InOctets         uint64
+	OutOctets        uint64
+	InMcastOctets    uint64
+	OutMcastOctets   uint64
+	InBcastOctets    uint64
+	OutBcastOctets   uint64
+	InNoECTPkts      uint64
+	InECT1Pkts       uint64
+	InECT0Pkts       uint64
+	InCEPkts         uint64
+}
+
+// NetDevSNMP6Icmp holds the ICMPv6 counters of an interface, the lines
+// starting with Icmp6 in /proc/net/dev_snmp6/<iface>.
+type NetDevSNMP6Icmp struct {
+	InMsgs       uint64
+	InErrors     uint64
+	OutMsgs      uint64
+	OutErrors    uint64
+	InCsumErrors uint64
+	// Received messages per ICMPv6 type. The map keys are the type names
+	// used by the kernel, e.g. "EchoReplies", or "Type<n>" for types without
+	// a name.
+	InTypes map[string]uint64
+	// Sent messages per ICMPv6 type, keyed like InTypes.
+	OutTypes map[string]uint64
+}
+
+// NetDevSNMP6Udp holds the UDP counters of an interface, the lines starting
+// with Udp6 or UdpLite6 in /proc/net/dev_snmp6/<iface>.
+type NetDevSNMP6Udp struct {
+	InDatagrams  uint64
+	NoPorts      uint64
+	InErrors     uint64
+	OutDatagrams uint64
+	RcvbufErrors uint64
+	SndbufErrors uint64
+	InCsumErrors uint64
+	IgnoredMulti uint64
+	MemErrors    uint64
+}
+
+// NetDevSNMP6Line holds the contents of /proc/net/dev_snmp6/<iface>.
+type NetDevSNMP6Line struct {
+	// The name of the interface.
+	Name string
+	// The index of the interface.
+	IfIndex uint64
+
+	Ip6      NetDevSNMP6Ip
+	Icmp6    NetDevSNMP6Icmp
+	Udp6     NetDevSNMP6Udp
+	UdpLite6 NetDevSNMP6Udp
+}
+
+// NetDevSNMP6 is parsed from /proc/net/dev_snmp6. The map keys are interface
+// names.
+type NetDevSNMP6 map[string]NetDevSNMP6Line
+
+// NetDevSNMP6 returns the per-interface IPv6 statistics read from
+// /proc/net/dev_snmp6.
+func (fs FS) NetDevSNMP6() (NetDevSNMP6, error) {
+	d, err := os.Open(fs.proc.Path("net/dev_snmp6"))
+	if err != nil {
+		return NetDevSNMP6{}, err
+	}
+	defer d.Close()
+
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return NetDevSNMP6{}, fmt.Errorf("could not read %s: %s", d.Name(), err)
+	}
+
+	nd := NetDevSNMP6{}
+	for _, name := range names {
+		line, err := fs.netDevSNMP6Iface(name)
+		if err != nil {
+			// The interface may have been removed since reading the directory.
+			if os.IsNotExist(err) {
+				continue
+			}
+			return NetDevSNMP6{}, err
+		}
+		nd[name] = line
+	}
+
+	return nd, nil
+}
+
+func (fs FS) netDevSNMP6Iface(name string) (NetDevSNMP6Line, error) {
+	f, err := os.Open(fs.proc.Path("net/dev_snmp6", name))
+	if err != nil {
+		return NetDevSNMP6Line{}, err
+	}
+	defer f.Close()
+
+	return parseNetDevSNMP6(name, f)
+}
+
+func parseNetDevSNMP6(name string, r io.Reader) (NetDevSNMP6Line, error) {
+	var (
+		line = NetDevSNMP6Line{
+			Name: name,
+			Icmp6: NetDevSNMP6Icmp{
+				InTypes:  map[string]uint64{},
+				OutTypes: map[string]uint64{},
+			},
+		}
+		s = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return NetDevSNMP6Line{}, fmt.Errorf("couldn't parse dev_snmp6 line %q of %s", s.Text(), name)
+		}
+
+		key := fields[0]
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return NetDevSNMP6Line{}, fmt.Errorf("couldn't parse %s (%s): %s", fields[1], key, err)
+		}
+
+		switch {
+		case key == "ifIndex":
+			line.IfIndex = value
+		case strings.HasPrefix(key, "Ip6"):
+			line.Ip6.set(strings.TrimPrefix(key, "Ip6"), value)
+		case strings.HasPrefix(key, "Icmp6"):
+			line.Icmp6.set(strings.TrimPrefix(key, "Icmp6"), value)
+		case strings.HasPrefix(key, "UdpLite6"):
+			line.UdpLite6.set(strings.TrimPrefix(key, "UdpLite6"), value)
+		case strings.HasPrefix(key, "Udp6"):
+			line.Udp6.set(strings.TrimPrefix(key, "Udp6"), value)
+		}
+	}
+
+	return line, s.Err()
+}
+
+func (ip *NetDevSNMP6Ip) set(key string, value uint64) {
+	switch key {
+	case "InReceives":
+		ip.InReceives = value
+	case "InHdrErrors":
+		ip.InHdrErrors = value
+	case "InTooBigErrors":
+		ip.InTooBigErrors = value
+	case "InNoRoutes":
+		ip.InNoRoutes = value
+	case "InAddrErrors":
+		ip.InAddrErrors = value
+	case "InUnknownProtos":
+		ip.InUnknownProtos = value
+	case "InTruncatedPkts":
+		ip.InTruncatedPkts = value
+	case "InDiscards":
+		ip.InDiscards = value
+	case "InDelivers":
+		ip.InDelivers = value
+	case "OutForwDatagrams":
+		ip.OutForwDatagrams = value
+	case "OutRequests":
+		ip.OutRequests = value
+	case "OutDiscards":
+		ip.OutDiscards = value
+	case "OutNoRoutes":
+		ip.OutNoRoutes = value
+	case "ReasmTimeout":
+		ip.ReasmTimeout = value
+	case "ReasmReqds":
+		ip.ReasmReqds = value
+	case "ReasmOKs":
+		ip.ReasmOKs = value
+	case "ReasmFails":
+		ip.ReasmFails = value
+	case "FragOKs":
+		ip.FragOKs = value
+	case "FragFails":
+		ip.FragFails = value
+	case "FragCreates":
+		ip.FragCreates = value
+	case "InMcastPkts":
+		ip.InMcastPkts = value
+	case "OutMcastPkts":
+		ip.OutMcastPkts = value
+	case "InOctets":
+		ip.InOctets = value
+	case "OutOctets":
+		ip.OutOctets = value
+	case "InMcastOctets":
+		ip.InMcastOctets = value
+	case "OutMcastOctets":
+		ip.OutMcastOctets = value
+	case "InBcastOctets":
+		ip.InBcastOctets = value
+	case "OutBcastOctets":
+		ip.OutBcastOctets = value
+	case "InNoECTPkts":
+		ip.InNoECTPkts = value
+	case "InECT1Pkts":
+		ip.InECT1Pkts = value
+	case "InECT0Pkts":
+		ip.InECT0Pkts = value
+	case "InCEPkts":
+		ip.InCEPkts = value
+	}
+}
+
+func (icmp *NetDevSNMP6Icmp) set(key string, value uint64) {
+	switch {
+	case key == "InMsgs":
+		icmp.InMsgs = value
+	case key == "InErrors":
+		icmp.InErrors = value
+	case key == "OutMsgs":
+		icmp.OutMsgs = value
+	case key == "OutErrors":
+		icmp.OutErrors = value
+	case key == "InCsumErrors":
+		icmp.InCsumErrors = value
+	case strings.HasPrefix(key, "In"):
+		icmp.InTypes[strings.TrimPrefix(key, "In")] = value
+	case strings.HasPrefix(key, "Out"):
+		icmp.OutTypes[strings.TrimPrefix(key, "Out")] = value
+	}
+}
+
+func (udp *NetDevSNMP6Udp) set(key string, value uint64) {
+	switch key {
+	case "InDatagrams":
+		udp.InDatagrams = value
+	case "NoPorts":
+		udp.NoPorts = value
+	case "InErrors":
+		udp.InErrors = value
+	case "OutDatagrams":
+		udp.OutDatagrams = value
+	case "RcvbufErrors":
+		udp.RcvbufErrors = value
+	case "SndbufErrors":
+		udp.SndbufErrors = value
+	case "InCsumErrors":
+		udp.InCsumErrors = value
+	case "IgnoredMulti":
+		udp.IgnoredMulti = value
+	case "MemErrors":
+		udp.MemErrors = value
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNetDevSNMP6(t *testing.T) {
+	nd, err := getProcFixtures(t).NetDevSNMP6()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 2, len(nd); want != have {
+		t.Fatalf("want %d parsed interfaces, have %d", want, have)
+	}
+
+	eth0 := nd["eth0"]
+	if want, have := "eth0", eth0.Name; want != have {
+		t.Errorf("want name %s, have %s", want, have)
+	}
+	if want, have := uint64(2), eth0.IfIndex; want != have {
+		t.Errorf("want ifIndex %d, have %d", want, have)
+	}
+
+	wantIP := NetDevSNMP6Ip{
+		InReceives:     15,
+		InDelivers:     15,
+		OutRequests:    31,
+		InMcastPkts:    6,
+		OutMcastPkts:   19,
+		InOctets:       1240,
+		OutOctets:      2478,
+		InMcastOctets:  420,
+		OutMcastOctets: 1462,
+		InNoECTPkts:    15,
+	}
+	if have := eth0.Ip6; wantIP != have {
+		t.Errorf("want %+v, have %+v", wantIP, have)
+	}
+
+	wantICMP := NetDevSNMP6Icmp{
+		InMsgs:   9,
+		InErrors: 1,
+		OutMsgs:  18,
+		InTypes: map[string]uint64{
+			"DestUnreachs":           1,
+			"RouterAdvertisements":   6,
+			"NeighborAdvertisements": 2,
+			"Type1":                  1,
+			"Type134":                6,
+			"Type136":                2,
+		},
+		OutTypes: map[string]uint64{
+			"RouterSolicits":   3,
+			"NeighborSolicits": 4,
+			"MLDv2Reports":     11,
+			"Type133":          3,
+			"Type135":          4,
+			"Type143":          11,
+		},
+	}
+	if have := eth0.Icmp6; !reflect.DeepEqual(wantICMP, have) {
+		t.Errorf("want %+v, have %+v", wantICMP, have)
+	}
+
+	if want, have := (NetDevSNMP6Udp{IgnoredMulti: 12}), eth0.Udp6; want != have {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+
+	lo := nd["lo"]
+	if want, have := (NetDevSNMP6Udp{InDatagrams: 2, OutDatagrams: 2}), lo.Udp6; want != have {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+}
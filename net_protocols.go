@@ -0,0 +1,222 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NetProtocolStatLine contains a single line parsed from /proc/net/protocols.
+type NetProtocolStatLine struct {
+	// The name of the protocol.
+	Name string
+	// The size, in bytes, of a given protocol structure, e.g. sizeof(struct tcp_sock).
+	Size uint64
+	// Number of sockets in use by this protocol.
+	Sockets int64
+	// Number of 4KB pages allocated by all sockets of this protocol,
+	// -1 if the protocol does not account memory.
+	Memory int64
+	// Memory pressure state: 1 if under pressure, 0 if not and -1 if the
+	// protocol does not implement memory pressure.
+	Pressure int
+	// Maximum protocol header size.
+	MaxHeader uint64
+	// Whether the protocol structures are allocated from a dedicated slab cache.
+	Slab bool
+	// Name of the module implementing the protocol, "kernel" if built in.
+	ModuleName string
+	// Operations implemented by the protocol.
+	Capabilities NetProtocolCapabilities
+}
+
+// NetProtocolCapabilities contains a list of booleans indicating which
+// socket operations a protocol implements.
+type NetProtocolCapabilities struct {
+	Close               bool // 'cl'
+	Connect             bool // 'co'
+	Disconnect          bool // 'di'
+	Accept              bool // 'ac'
+	IoCtl               bool // 'io'
+	Init                bool // 'in'
+	Destroy             bool // 'de'
+	Shutdown            bool // 'sh'
+	SetSockOpt          bool // 'ss'
+	GetSockOpt          bool // 'gs'
+	SendMsg             bool // 'se'
+	RecvMsg             bool // 're'
+	SendPage            bool // 'sp', not reported by newer kernels
+	Bind                bool // 'bi'
+	BacklogRcv          bool // 'br'
+	Hash                bool // 'ha'
+	UnHash              bool // 'uh'
+	GetPort             bool // 'gp'
+	EnterMemoryPressure bool // 'em'
+}
+
+// NetProtocolStats stores the contents from /proc/net/protocols. The map keys
+// are protocol names.
+type NetProtocolStats map[string]NetProtocolStatLine
+
+// NetProtocols reads the stats from /proc/net/protocols and returns a map of
+// NetProtocolStatLine entries.
+func (fs FS) NetProtocols() (NetProtocolStats, error) {
+	f, err := os.Open(fs.proc.Path("net/protocols"))
+	if err != nil {
+		return NetProtocolStats{}, err
+	}
+	defer f.Close()
+
+	return parseNetProtocols(f)
+}
+
+func parseNetProtocols(r io.Reader) (NetProtocolStats, error) {
+	var (
+		nps = NetProtocolStats{}
+		s   = bufio.NewScanner(r)
+	)
+
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("net/protocols corrupt: missing header")
+	}
+	header := strings.Fields(s.Text())
+	if len(header) < 8 {
+		return nil, fmt.Errorf("net/protocols corrupt: %d header columns, at least 8 expected", len(header))
+	}
+
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != len(header) {
+			return nil, fmt.Errorf("%d columns were detected, but %d were expected", len(fields), len(header))
+		}
+
+		line, err := parseNetProtocolLine(header, fields)
+		if err != nil {
+			return nil, err
+		}
+		nps[line.Name] = *line
+	}
+
+	return nps, s.Err()
+}
+
+func parseNetProtocolLine(header, fields []string) (*NetProtocolStatLine, error) {
+	var (
+		line = &NetProtocolStatLine{Name: fields[0], ModuleName: fields[7]}
+		err  error
+	)
+
+	if line.Size, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return nil, err
+	}
+	if line.Sockets, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+		return nil, err
+	}
+	if line.Memory, err = strconv.ParseInt(fields[3], 10, 64); err != nil {
+		return nil, err
+	}
+	switch fields[4] {
+	case "yes":
+		line.Pressure = 1
+	case "no":
+		line.Pressure = 0
+	case "NI":
+		line.Pressure = -1
+	default:
+		return nil, fmt.Errorf("unable to parse memory pressure %q of protocol %s", fields[4], line.Name)
+	}
+	if line.MaxHeader, err = strconv.ParseUint(fields[5], 10, 64); err != nil {
+		return nil, err
+	}
+	if line.Slab, err = parseProtocolBool(fields[6]); err != nil {
+		return nil, err
+	}
+
+	for i := 8; i < len(header); i++ {
+		capable, err := parseProtocolBool(fields[i])
+		if err != nil {
+			return nil, err
+		}
+		line.Capabilities.set(header[i], capable)
+	}
+
+	return line, nil
+}
+
+// set sets the capability identified by its /proc/net/protocols column name.
+func (pc *NetProtocolCapabilities) set(column string, capable bool) {
+	switch column {
+	case "cl":
+		pc.Close = capable
+	case "co":
+		pc.Connect = capable
+	case "di":
+		pc.Disconnect = capable
+	case "ac":
+		pc.Accept = capable
+	case "io":
+		pc.IoCtl = capable
+	case "in":
+		pc.Init = capable
+	case "de":
+		pc.Destroy = capable
+	case "sh":
+		pc.Shutdown = capable
+	case "ss":
+		pc.SetSockOpt = capable
+	case "gs":
+		pc.GetSockOpt = capable
+	case "se":
+		pc.SendMsg = capable
+	case "re":
+		pc.RecvMsg = capable
+	case "sp":
+		pc.SendPage = capable
+	case "bi":
+		pc.Bind = capable
+	case "br":
+		pc.BacklogRcv = capable
+	case "ha":
+		pc.Hash = capable
+	case "uh":
+		pc.UnHash = capable
+	case "gp":
+		pc.GetPort = capable
+	case "em":
+		pc.EnterMemoryPressure = capable
+	}
+}
+
+func parseProtocolBool(s string) (bool, error) {
+	switch s {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unable to parse boolean %q in net/protocols", s)
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"testing"
+)
+
+func TestNetProtocols(t *testing.T) {
+	protocolStats, err := getProcFixtures(t).NetProtocols()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 8, len(protocolStats); want != have {
+		t.Errorf("want %d parsed protocols, have %d", want, have)
+	}
+
+	want := NetProtocolStatLine{
+		Name:       "TCP",
+		Size:       1984,
+		Sockets:    93064,
+		Memory:     1225378,
+		Pressure:   1,
+		MaxHeader:  320,
+		Slab:       true,
+		ModuleName: "kernel",
+		Capabilities: NetProtocolCapabilities{
+			Close:               true,
+			Connect:             true,
+			Disconnect:          true,
+			Accept:              true,
+			IoCtl:               true,
+			Init:                true,
+			Destroy:             true,
+			Shutdown:            true,
+			SetSockOpt:          true,
+			GetSockOpt:          true,
+			SendMsg:             true,
+			RecvMsg:             true,
+			SendPage:            true,
+			Bind:                false,
+			BacklogRcv:          true,
+			Hash:                true,
+			UnHash:              true,
+			GetPort:             true,
+			EnterMemoryPressure: true,
+		},
+	}
+	if have := protocolStats["TCP"]; want != have {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+
+	packet := protocolStats["PACKET"]
+	if want, have := int64(-1), packet.Memory; want != have {
+		t.Errorf("want PACKET memory %d, have %d", want, have)
+	}
+	if want, have := -1, packet.Pressure; want != have {
+		t.Errorf("want PACKET pressure %d, have %d", want, have)
+	}
+	if packet.Slab {
+		t.Error("want PACKET not to use a slab cache")
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// RouteFlags holds the flags of a routing table entry, see
+// include/uapi/linux/route.h and include/uapi/linux/ipv6_route.h.
+type RouteFlags uint32
+
+// Routing table entry flags.
+const (
+	// RouteFlagUp is set if the route is usable.
+	RouteFlagUp RouteFlags = 0x0001
+	// RouteFlagGateway is set if the destination is reached via a gateway.
+	RouteFlagGateway RouteFlags = 0x0002
+	// RouteFlagHost is set for host routes (as opposed to network routes).
+	RouteFlagHost RouteFlags = 0x0004
+	// RouteFlagReject is set for routes that reject all traffic.
+	RouteFlagReject RouteFlags = 0x0200
+)
+
+// Up reports whether the route is usable.
+func (f RouteFlags) Up() bool { return f&RouteFlagUp != 0 }
+
+// Gateway reports whether the destination is reached via a gateway.
+func (f RouteFlags) Gateway() bool { return f&RouteFlagGateway != 0 }
+
+// Host reports whether the route is a host route.
+func (f RouteFlags) Host() bool { return f&RouteFlagHost != 0 }
+
+// Reject reports whether the route rejects all traffic.
+func (f RouteFlags) Reject() bool { return f&RouteFlagReject != 0 }
+
+// NetRoute contains a single row of /proc/net/route.
+type NetRoute struct {
+	// Name of the outgoing interface.
+	Iface string
+	// Destination network.
+	Destination net.IPNet
+	// Gateway address, 0.0.0.0 for directly connected networks.
+	Gateway net.IP
+	// Route flags.
+	Flags RouteFlags
+	// Number of references to the route.
+	RefCnt uint64
+	// Number of lookups of the route.
+	Use uint64
+	// Distance to the target.
+	Metric uint32
+	// Maximum transmission unit for TCP connections over the route.
+	MTU uint32
+	// Default TCP window size for connections over the route.
+	Window uint32
+	// Initial round trip time.
+	IRTT uint32
+}
+
+// IsDefault reports whether the route is a default route.
+func (r NetRoute) IsDefault() bool {
+	ones, _ := r.Destination.Mask.Size()
+	return ones == 0
+}
+
+// NetIPv6Route contains a single row of /proc/net/ipv6_route.
+type NetIPv6Route struct {
+	// Destination network.
+	Destination net.IPNet
+	// Source network, used with source routing.
+	Source net.IPNet
+	// Next hop address, :: for directly connected networks.
+	NextHop net.IP
+	// Distance to the target.
+	Metric uint32
+	// Number of references to the route.
+	RefCnt uint32
+	// Number of lookups of the route.
+	Use uint32
+	// Route flags.
+	Flags RouteFlags
+	// Name of the outgoing interface.
+	Iface string
+}
+
+// IsDefault reports whether the route is a default route.
+func (r NetIPv6Route) IsDefault() bool {
+	ones, _ := r.Destination.Mask.Size()
+	return ones == 0
+}
+
+// NetRoute reads the IPv4 routing table from /proc/net/route.
+func (fs FS) NetRoute() ([]NetRoute, error) {
+	f, err := os.Open(fs.proc.Path("net/route"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseNetRoute(f)
+}
+
+// NetIPv6Route reads the IPv6 routing table from /proc/net/ipv6_route.
+func (fs FS) NetIPv6Route() ([]NetIPv6Route, error) {
+	f, err := os.Open(fs.proc.Path("net/ipv6_route"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseNetIPv6Route(f)
+}
+
+func parseNetRoute(r io.Reader) ([]NetRoute, error) {
+	var (
+		routes []NetRoute
+		s      = bufio.NewScanner(r)
+	)
+
+	for n := 0; s.Scan(); n++ {
+		// Skip the header line.
+		if n == 0 {
+			continue
+		}
+
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 11 {
+			return nil, fmt.Errorf("invalid net/route line, %d fields were detected, but 11 were expected", len(fields))
+		}
+
+		var (
+			route = NetRoute{Iface: fields[0]}
+			err   error
+		)
+
+		dst, err := parseLittleEndianIPv4(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		if route.Gateway, err = parseLittleEndianIPv4(fields[2]); err != nil {
+			return nil, err
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (route flags): %s", fields[3], err)
+		}
+		route.Flags = RouteFlags(flags)
+		if route.RefCnt, err = strconv.ParseUint(fields[4], 10, 64); err != nil {
+			return nil, err
+		}
+		if route.Use, err = strconv.ParseUint(fields[5], 10, 64); err != nil {
+			return nil, err
+		}
+		if route.Metric, err = parseUint32(fields[6], 10); err != nil {
+			return nil, err
+		}
+		mask, err := parseLittleEndianIPv4(fields[7])
+		if err != nil {
+			return nil, err
+		}
+		route.Destination = net.IPNet{IP: dst, Mask: net.IPMask(mask)}
+		if route.MTU, err = parseUint32(fields[8], 10); err != nil {
+			return nil, err
+		}
+		if route.Window, err = parseUint32(fields[9], 10); err != nil {
+			return nil, err
+		}
+		if route.IRTT, err = parseUint32(fields[10], 10); err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, s.Err()
+}
+
+func parseNetIPv6Route(r io.Reader) ([]NetIPv6Route, error) {
+	var (
+		routes []NetIPv6Route
+		s      = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 10 {
+			return nil, fmt.Errorf("invalid net/ipv6_route line, %d fields were detected, but 10 were expected", len(fields))
+		}
+
+		var (
+			route = NetIPv6Route{Iface: fields[9]}
+			err   error
+		)
+
+		if route.Destination, err = parseIPv6Net(fields[0], fields[1]); err != nil {
+			return nil, err
+		}
+		if route.Source, err = parseIPv6Net(fields[2], fields[3]); err != nil {
+			return nil, err
+		}
+		if route.NextHop, err = parseIPv6(fields[4]); err != nil {
+			return nil, err
+		}
+		if route.Metric, err = parseUint32(fields[5], 16); err != nil {
+			return nil, err
+		}
+		if route.RefCnt, err = parseUint32(fields[6], 16); err != nil {
+			return nil, err
+		}
+		if route.Use, err = parseUint32(fields[7], 16); err != nil {
+			return nil, err
+		}
+		flags, err := parseUint32(fields[8], 16)
+		if err != nil {
+			return nil, err
+		}
+		route.Flags = RouteFlags(flags)
+
+		routes = append(routes, route)
+	}
+
+	return routes, s.Err()
+}
+
+// parseLittleEndianIPv4 parses an IPv4 address printed as a hexadecimal
+// number in host (little-endian) byte order.
+func parseLittleEndianIPv4(s string) (net.IP, error) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IPv4 address: %s", s)
+	}
+
+	return net.IP{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)}, nil
+}
+
+// parseIPv6 parses an IPv6 address printed as 32 hexadecimal digits in
+// network byte order.
+func parseIPv6(s string) (net.IP, error) {
+	ip, err := hex.DecodeString(s)
+	if err != nil || len(ip) != net.IPv6len {
+		return nil, fmt.Errorf("invalid IPv6 address: %s", s)
+	}
+
+	return net.IP(ip), nil
+}
+
+func parseIPv6Net(addr, prefixLen string) (net.IPNet, error) {
+	ip, err := parseIPv6(addr)
+	if err != nil {
+		return net.IPNet{}, err
+	}
+	ones, err := strconv.ParseUint(prefixLen, 16, 8)
+	if err != nil || ones > 8*net.IPv6len {
+		return net.IPNet{}, fmt.Errorf("invalid IPv6 prefix length: %s", prefixLen)
+	}
+
+	return net.IPNet{IP: ip, Mask: net.CIDRMask(int(ones), 8*net.IPv6len)}, nil
+}
+
+func parseUint32(s string, base int) (uint32, error) {
+	v, err := strconv.ParseUint(s, base, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(v), nil
+}
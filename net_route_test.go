@@ -0,0 +1,128 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNetRoute(t *testing.T) {
+	fs, err := NewFS(procTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes, err := fs.NetRoute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 3, len(routes); want != have {
+		t.Fatalf("want %d parsed routes, have %d", want, have)
+	}
+
+	for i, want := range []struct {
+		iface       string
+		destination string
+		gateway     string
+		flags       RouteFlags
+		metric      uint32
+		mtu         uint32
+		isDefault   bool
+	}{
+		{"eth0", "0.0.0.0/0", "192.168.2.1", RouteFlagUp | RouteFlagGateway, 100, 0, true},
+		{"eth0", "192.168.2.0/24", "0.0.0.0", RouteFlagUp, 100, 0, false},
+		{"docker0", "172.17.0.0/16", "0.0.0.0", RouteFlagUp, 0, 1500, false},
+	} {
+		have := routes[i]
+		if want.iface != have.Iface {
+			t.Errorf("%d: want iface %s, have %s", i, want.iface, have.Iface)
+		}
+		if want.destination != have.Destination.String() {
+			t.Errorf("%d: want destination %s, have %s", i, want.destination, have.Destination.String())
+		}
+		if !net.ParseIP(want.gateway).Equal(have.Gateway) {
+			t.Errorf("%d: want gateway %s, have %s", i, want.gateway, have.Gateway)
+		}
+		if want.flags != have.Flags {
+			t.Errorf("%d: want flags %#x, have %#x", i, want.flags, have.Flags)
+		}
+		if want.metric != have.Metric {
+			t.Errorf("%d: want metric %d, have %d", i, want.metric, have.Metric)
+		}
+		if want.mtu != have.MTU {
+			t.Errorf("%d: want mtu %d, have %d", i, want.mtu, have.MTU)
+		}
+		if want.isDefault != have.IsDefault() {
+			t.Errorf("%d: want default %t, have %t", i, want.isDefault, have.IsDefault())
+		}
+	}
+}
+
+func TestNetIPv6Route(t *testing.T) {
+	fs, err := NewFS(procTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes, err := fs.NetIPv6Route()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 4, len(routes); want != have {
+		t.Fatalf("want %d parsed routes, have %d", want, have)
+	}
+
+	for i, want := range []struct {
+		iface       string
+		destination string
+		nextHop     string
+		metric      uint32
+		use         uint32
+		up          bool
+		gateway     bool
+		reject      bool
+		isDefault   bool
+	}{
+		{"eth0", "2001:db8::/64", "::", 256, 0, true, false, false, false},
+		{"eth0", "fe80::/64", "::", 256, 0, true, false, false, false},
+		{"eth0", "::/0", "fe80::1", 1024, 5, true, true, false, true},
+		{"lo", "::/0", "::", 0xffffffff, 0, false, false, true, true},
+	} {
+		have := routes[i]
+		if want.iface != have.Iface {
+			t.Errorf("%d: want iface %s, have %s", i, want.iface, have.Iface)
+		}
+		if want.destination != have.Destination.String() {
+			t.Errorf("%d: want destination %s, have %s", i, want.destination, have.Destination.String())
+		}
+		if !net.ParseIP(want.nextHop).Equal(have.NextHop) {
+			t.Errorf("%d: want next hop %s, have %s", i, want.nextHop, have.NextHop)
+		}
+		if want.metric != have.Metric {
+			t.Errorf("%d: want metric %d, have %d", i, want.metric, have.Metric)
+		}
+		if want.use != have.Use {
+			t.Errorf("%d: want use %d, have %d", i, want.use, have.Use)
+		}
+		if want.up != have.Flags.Up() || want.gateway != have.Flags.Gateway() || want.reject != have.Flags.Reject() {
+			t.Errorf("%d: unexpected flags %#x", i, have.Flags)
+		}
+		if want.isDefault != have.IsDefault() {
+			t.Errorf("%d: want default %t, have %t", i, want.isDefault, have.IsDefault())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// For the proc file format details,
+// see https://elixir.bootlin.com/linux/v5.0/source/net/core/net-procfs.c#L162
+// and https://elixir.bootlin.com/linux/v5.0/source/include/linux/netdevice.h#L2998.
+
+// Minimum number of columns in a softnet_stat line. Older kernels omit the
+// trailing received_rps and flow_limit_count columns.
+const minSoftnetColumns = 3
+
+// SoftnetStat contains a single row of data from /proc/net/softnet_stat.
+type SoftnetStat struct {
+	// Index of the CPU the row belongs to.
+	CPU int
+	// Number of processed packets.
+	Processed uint32
+	// Number of dropped packets because the backlog queue was full.
+	Dropped uint32
+	// Number of times processing packets ran out of quota or time.
+	TimeSqueezed uint32
+	// Number of times the CPU has been woken up to process packets via RPS.
+	// Only available on kernels with RPS support.
+	ReceivedRPS uint32
+	// Number of times the flow limit has been reached.
+	// Only available on kernels with flow limit support.
+	FlowLimitCount uint32
+}
+
+// NetSoftnetStat reads the per-CPU packet processing statistics from
+// /proc/net/softnet_stat.
+func (fs FS) NetSoftnetStat() ([]SoftnetStat, error) {
+	f, err := os.Open(fs.proc.Path("net/softnet_stat"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseSoftnet(f)
+}
+
+func parseSoftnet(r io.Reader) ([]SoftnetStat, error) {
+	var (
+		stats []SoftnetStat
+		s     = bufio.NewScanner(r)
+	)
+
+	for cpu := 0; s.Scan(); cpu++ {
+		columns := strings.Fields(s.Text())
+		if len(columns) < minSoftnetColumns {
+			return nil, fmt.Errorf("%d columns were detected, but at least %d were expected",
+				len(columns), minSoftnetColumns)
+		}
+
+		values := make([]uint32, len(columns))
+		for i, column := range columns {
+			v, err := strconv.ParseUint(column, 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse %q (softnet_stat): %s", column, err)
+			}
+			values[i] = uint32(v)
+		}
+
+		stat := SoftnetStat{
+			CPU:          cpu,
+			Processed:    values[0],
+			Dropped:      values[1],
+			TimeSqueezed: values[2],
+		}
+		// Columns 3 to 7 are always zero, they used to hold fastroute
+		// counters. Column 8 is cpu_collision, which is always zero as well.
+		if len(values) > 9 {
+			stat.ReceivedRPS = values[9]
+		}
+		if len(values) > 10 {
+			stat.FlowLimitCount = values[10]
+		}
+		// Newer kernels skip offline CPUs and report the CPU index explicitly.
+		if len(values) > 12 {
+			stat.CPU = int(values[12])
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, s.Err()
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNetSoftnet(t *testing.T) {
+	fs, err := NewFS(procTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := fs.NetSoftnetStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SoftnetStat{
+		{CPU: 0, Processed: 0x00015c73, Dropped: 0x00020e76, TimeSqueezed: 0xf0000769},
+		{CPU: 1, Processed: 0x01663fb2, Dropped: 0x00000000, TimeSqueezed: 0x000109a4},
+	}
+
+	if !reflect.DeepEqual(want, entries) {
+		t.Errorf("want %v, have %v", want, entries)
+	}
+}
+
+func TestParseSoftnetColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    SoftnetStat
+		invalid bool
+	}{
+		{
+			name: "with RPS and flow limit",
+			line: "2a4ef8d4 00000000 0000013b 00000000 00000000 00000000 00000000 00000000 00000000 00bc76f3 00000002",
+			want: SoftnetStat{Processed: 0x2a4ef8d4, TimeSqueezed: 0x13b, ReceivedRPS: 0xbc76f3, FlowLimitCount: 2},
+		},
+		{
+			name: "with backlog length and CPU index",
+			line: "0002b7f9 00000000 00000001 00000000 00000000 00000000 00000000 00000000 00000000 0000e4a4 00000000 00000000 00000002",
+			want: SoftnetStat{CPU: 2, Processed: 0x2b7f9, TimeSqueezed: 1, ReceivedRPS: 0xe4a4},
+		},
+		{
+			name:    "too few columns",
+			line:    "00000010 00000002",
+			invalid: true,
+		},
+		{
+			name:    "not hexadecimal",
+			line:    "0000001g 00000002 00000003",
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats, err := parseSoftnet(strings.NewReader(test.line + "\n"))
+			if test.invalid {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want, have := []SoftnetStat{test.want}, stats; !reflect.DeepEqual(want, have) {
+				t.Errorf("want %v, have %v", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Wireless models the statistics of a single wireless interface, read from
+// /proc/net/wireless.
+type Wireless struct {
+	// The name of the interface.
+	Name string
+
+	// Device dependent status, usually the operating mode.
+	Status uint64
+
+	// Overall quality of the link.
+	QualityLink int
+	// Received signal strength, in dBm or in arbitrary units depending on
+	// the driver.
+	QualityLevel int
+	// Background noise level, in the same unit as QualityLevel.
+	QualityNoise int
+
+	// Packets discarded because of a wrong network ID (nwid / essid).
+	DiscardedNwid int
+	// Packets discarded because they could not be decrypted.
+	DiscardedCrypt int
+	// Packets discarded because they could not be reassembled.
+	DiscardedFrag int
+	// Packets discarded after reaching the maximum number of MAC retries.
+	DiscardedRetry int
+	// Packets discarded for other reasons.
+	DiscardedMisc int
+
+	// Number of beacons missed from the access point.
+	MissedBeacon int
+}
+
+// Wireless returns the statistics of all wireless interfaces read from
+// /proc/net/wireless.
+func (fs FS) Wireless() ([]Wireless, error) {
+	f, err := os.Open(fs.proc.Path("net/wireless"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseWireless(f)
+}
+
+func parseWireless(r io.Reader) ([]Wireless, error) {
+	var (
+		interfaces []Wireless
+		s          = bufio.NewScanner(r)
+	)
+
+	for n := 0; s.Scan(); n++ {
+		// Skip the 2 header lines.
+		if n < 2 {
+			continue
+		}
+
+		parts := strings.SplitN(s.Text(), ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("invalid net/wireless line, missing colon")
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			return nil, errors.New("invalid net/wireless line, empty interface name")
+		}
+
+		fields := strings.Fields(parts[1])
+		if len(fields) != 10 {
+			return nil, fmt.Errorf("invalid net/wireless line for %s, %d fields were detected, but 10 were expected", name, len(fields))
+		}
+
+		status, err := strconv.ParseUint(fields[0], 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (wireless status): %s", fields[0], err)
+		}
+
+		// The quality values are followed by a '.' if they were updated
+		// since the last read, and by a ' ' otherwise.
+		values := make([]int, len(fields)-1)
+		for i, field := range fields[1:] {
+			values[i], err = strconv.Atoi(strings.TrimSuffix(field, "."))
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse %s (wireless %s): %s", field, name, err)
+			}
+		}
+
+		interfaces = append(interfaces, Wireless{
+			Name:           name,
+			Status:         status,
+			QualityLink:    values[0],
+			QualityLevel:   values[1],
+			QualityNoise:   values[2],
+			DiscardedNwid:  values[3],
+			DiscardedCrypt: values[4],
+			DiscardedFrag:  values[5],
+			DiscardedRetry: values[6],
+			DiscardedMisc:  values[7],
+			MissedBeacon:   values[8],
+		})
+	}
+
+	return interfaces, s.Err()
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWireless(t *testing.T) {
+	interfaces, err := getProcFixtures(t).Wireless()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Wireless{
+		{
+			Name:           "wlan0",
+			QualityLink:    70,
+			QualityLevel:   -40,
+			QualityNoise:   -256,
+			DiscardedCrypt: 1,
+			DiscardedRetry: 12,
+			DiscardedMisc:  3,
+			MissedBeacon:   7,
+		},
+		{
+			Name:          "wlan1",
+			QualityLink:   30,
+			QualityLevel:  -80,
+			QualityNoise:  -256,
+			DiscardedNwid: 5,
+			DiscardedFrag: 2,
+		},
+	}
+
+	if !reflect.DeepEqual(want, interfaces) {
+		t.Errorf("want %+v, have %+v", want, interfaces)
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/prometheus/procfs/internal/fs"
 )
@@ -30,6 +32,8 @@ type Proc struct {
 	PID int
 
 	fs fs.FS
+	// Directory of the process in handle mode, see ProcHandle.
+	dir *os.File
 }
 
 // Procs represents a list of Proc structs.
@@ -39,6 +43,27 @@ func (p Procs) Len() int           { return len(p) }
 func (p Procs) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 func (p Procs) Less(i, j int) bool { return p[i].PID < p[j].PID }
 
+// PermissionError is returned when a file of a process can't be accessed
+// with the current privileges, e.g. reading the kernel stack without
+// CAP_SYS_ADMIN.
+type PermissionError struct {
+	// The process ID.
+	PID int
+	// The file below /proc/[pid].
+	File string
+	// The underlying error.
+	Err error
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("no permission to access /proc/%d/%s: %s", e.PID, e.File, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
 // Self returns a process for the current process read via /proc/self.
 func Self() (Proc, error) {
 	fs, err := NewFS(DefaultMountPoint)
@@ -114,13 +139,7 @@ func (fs FS) AllProcs() (Procs, error) {
 
 // CmdLine returns the command line of a process.
 func (p Proc) CmdLine() ([]string, error) {
-	f, err := os.Open(p.path("cmdline"))
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	data, err := ioutil.ReadAll(f)
+	data, err := p.readFile("cmdline")
 	if err != nil {
 		return nil, err
 	}
@@ -134,13 +153,7 @@ func (p Proc) CmdLine() ([]string, error) {
 
 // Comm returns the command name of a process.
 func (p Proc) Comm() (string, error) {
-	f, err := os.Open(p.path("comm"))
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	data, err := ioutil.ReadAll(f)
+	data, err := p.readFile("comm")
 	if err != nil {
 		return "", err
 	}
@@ -150,7 +163,7 @@ func (p Proc) Comm() (string, error) {
 
 // Executable returns the absolute path of the executable command of a process.
 func (p Proc) Executable() (string, error) {
-	exe, err := os.Readlink(p.path("exe"))
+	exe, err := p.readlink("exe")
 	if os.IsNotExist(err) {
 		return "", nil
 	}
@@ -160,7 +173,7 @@ func (p Proc) Executable() (string, error) {
 
 // Cwd returns the absolute path to the current working directory of the process.
 func (p Proc) Cwd() (string, error) {
-	wd, err := os.Readlink(p.path("cwd"))
+	wd, err := p.readlink("cwd")
 	if os.IsNotExist(err) {
 		return "", nil
 	}
@@ -170,7 +183,7 @@ func (p Proc) Cwd() (string, error) {
 
 // RootDir returns the absolute path to the process's root directory (as set by chroot)
 func (p Proc) RootDir() (string, error) {
-	rdir, err := os.Readlink(p.path("root"))
+	rdir, err := p.readlink("root")
 	if os.IsNotExist(err) {
 		return "", nil
 	}
@@ -208,7 +221,7 @@ func (p Proc) FileDescriptorTargets() ([]string, error) {
 	targets := make([]string, len(names))
 
 	for i, name := range names {
-		target, err := os.Readlink(p.path("fd", name))
+		target, err := p.readlink("fd", name)
 		if err == nil {
 			targets[i] = target
 		}
@@ -231,23 +244,92 @@ func (p Proc) FileDescriptorsLen() (int, error) {
 // MountStats retrieves statistics and configuration for mount points in a
 // process's namespace.
 func (p Proc) MountStats() ([]*Mount, error) {
-	f, err := os.Open(p.path("mountstats"))
+	data, err := p.readFile("mountstats")
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	return parseMountStats(f)
+	return parseMountStats(bytes.NewReader(data))
 }
 
 func (p Proc) fileDescriptors() ([]string, error) {
-	d, err := os.Open(p.path("fd"))
+	return p.readDirNames("fd")
+}
+
+// readFile reads the file of the process at path name.
+func (p Proc) readFile(name ...string) ([]byte, error) {
+	if p.dir != nil {
+		return p.readFileAt(filepath.Join(name...))
+	}
+
+	return ioutil.ReadFile(p.path(name...))
+}
+
+// readPrivilegedFile reads a file of the process that may require privileges,
+// returning a PermissionError if access is denied.
+func (p Proc) readPrivilegedFile(name string) ([]byte, error) {
+	data, err := p.readFile(name)
+	if os.IsPermission(err) {
+		return nil, &PermissionError{PID: p.PID, File: name, Err: err}
+	}
+
+	return data, err
+}
+
+// writeFile writes data to the existing file of the process at path name.
+func (p Proc) writeFile(data []byte, name ...string) error {
+	if p.dir != nil {
+		return p.writeFileAt(filepath.Join(name...), data)
+	}
+
+	f, err := os.OpenFile(p.path(name...), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// readlink returns the target of the symbolic link of the process at path
+// name.
+func (p Proc) readlink(name ...string) (string, error) {
+	if p.dir != nil {
+		target, err := readlinkat(p.dir, filepath.Join(name...))
+		return target, p.handleErr(err)
+	}
+
+	return os.Readlink(p.path(name...))
+}
+
+// readDirNames returns the names of the entries of the directory of the
+// process at path name.
+func (p Proc) readDirNames(name ...string) ([]string, error) {
+	var (
+		d   *os.File
+		err error
+	)
+	if p.dir != nil {
+		d, err = openat(p.dir, filepath.Join(name...), os.O_RDONLY)
+		err = p.handleErr(err)
+	} else {
+		d, err = os.Open(p.path(name...))
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer d.Close()
 
 	names, err := d.Readdirnames(-1)
+	if p.dir != nil {
+		err = p.handleErr(err)
+	}
+	if err == ErrProcGone {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not read %s: %s", d.Name(), err)
 	}
@@ -255,6 +337,19 @@ func (p Proc) fileDescriptors() ([]string, error) {
 	return names, nil
 }
 
+// isProcGone returns whether err was caused by the process exiting while its
+// files were being read.
+func isProcGone(err error) bool {
+	if err == ErrProcGone || os.IsNotExist(err) {
+		return true
+	}
+	if pe, ok := err.(*os.PathError); ok {
+		return pe.Err == syscall.ESRCH
+	}
+
+	return false
+}
+
 func (p Proc) path(pa ...string) string {
 	return p.fs.Path(append([]string{strconv.Itoa(p.PID)}, pa...)...)
 }
@@ -0,0 +1,56 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProcAutogroup models the content of /proc/[pid]/autogroup, the scheduler
+// autogroup of the process. See sched(7).
+type ProcAutogroup struct {
+	// Name of the autogroup, e.g. /autogroup-42.
+	Name string
+	// Nice value of the autogroup, applied to the group as a whole.
+	Nice int
+}
+
+// Autogroup returns the scheduler autogroup of the process, read from
+// /proc/[pid]/autogroup. The file only exists on kernels built with
+// CONFIG_SCHED_AUTOGROUP.
+func (p Proc) Autogroup() (ProcAutogroup, error) {
+	data, err := p.readFile("autogroup")
+	if err != nil {
+		return ProcAutogroup{}, err
+	}
+
+	return parseAutogroup(strings.TrimSpace(string(data)))
+}
+
+func parseAutogroup(s string) (ProcAutogroup, error) {
+	// The content looks like "/autogroup-42 nice 0".
+	fields := strings.Fields(s)
+	if len(fields) != 3 || fields[1] != "nice" {
+		return ProcAutogroup{}, fmt.Errorf("invalid autogroup line: %q", s)
+	}
+
+	nice, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return ProcAutogroup{}, fmt.Errorf("couldn't parse %s (autogroup nice): %s", fields[2], err)
+	}
+
+	return ProcAutogroup{Name: fields[0], Nice: nice}, nil
+}
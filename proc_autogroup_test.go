@@ -0,0 +1,44 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import "testing"
+
+func TestAutogroup(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := p.Autogroup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ProcAutogroup{Name: "/autogroup-112", Nice: 5}); want != have {
+		t.Errorf("want autogroup %+v, have %+v", want, have)
+	}
+}
+
+func TestParseAutogroupErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"/autogroup-1 nice",
+		"/autogroup-1 prio 0",
+		"/autogroup-1 nice x",
+	} {
+		if _, err := parseAutogroup(line); err == nil {
+			t.Errorf("want an error for %q", line)
+		}
+	}
+}
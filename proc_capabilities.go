@@ -0,0 +1,153 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability is a Linux capability as defined in linux/capability.h.
+type Capability uint
+
+// Linux capabilities, see capabilities(7).
+const (
+	CapChown Capability = iota
+	CapDacOverride
+	CapDacReadSearch
+	CapFowner
+	CapFsetid
+	CapKill
+	CapSetgid
+	CapSetuid
+	CapSetpcap
+	CapLinuxImmutable
+	CapNetBindService
+	CapNetBroadcast
+	CapNetAdmin
+	CapNetRaw
+	CapIpcLock
+	CapIpcOwner
+	CapSysModule
+	CapSysRawio
+	CapSysChroot
+	CapSysPtrace
+	CapSysPacct
+	CapSysAdmin
+	CapSysBoot
+	CapSysNice
+	CapSysResource
+	CapSysTime
+	CapSysTtyConfig
+	CapMknod
+	CapLease
+	CapAuditWrite
+	CapAuditControl
+	CapSetfcap
+	CapMacOverride
+	CapMacAdmin
+	CapSyslog
+	CapWakeAlarm
+	CapBlockSuspend
+	CapAuditRead
+	CapPerfmon
+	CapBpf
+	CapCheckpointRestore
+)
+
+var capabilityNames = [...]string{
+	CapChown:             "cap_chown",
+	CapDacOverride:       "cap_dac_override",
+	CapDacReadSearch:     "cap_dac_read_search",
+	CapFowner:            "cap_fowner",
+	CapFsetid:            "cap_fsetid",
+	CapKill:              "cap_kill",
+	CapSetgid:            "cap_setgid",
+	CapSetuid:            "cap_setuid",
+	CapSetpcap:           "cap_setpcap",
+	CapLinuxImmutable:    "cap_linux_immutable",
+	CapNetBindService:    "cap_net_bind_service",
+	CapNetBroadcast:      "cap_net_broadcast",
+	CapNetAdmin:          "cap_net_admin",
+	CapNetRaw:            "cap_net_raw",
+	CapIpcLock:           "cap_ipc_lock",
+	CapIpcOwner:          "cap_ipc_owner",
+	CapSysModule:         "cap_sys_module",
+	CapSysRawio:          "cap_sys_rawio",
+	CapSysChroot:         "cap_sys_chroot",
+	CapSysPtrace:         "cap_sys_ptrace",
+	CapSysPacct:          "cap_sys_pacct",
+	CapSysAdmin:          "cap_sys_admin",
+	CapSysBoot:           "cap_sys_boot",
+	CapSysNice:           "cap_sys_nice",
+	CapSysResource:       "cap_sys_resource",
+	CapSysTime:           "cap_sys_time",
+	CapSysTtyConfig:      "cap_sys_tty_config",
+	CapMknod:             "cap_mknod",
+	CapLease:             "cap_lease",
+	CapAuditWrite:        "cap_audit_write",
+	CapAuditControl:      "cap_audit_control",
+	CapSetfcap:           "cap_setfcap",
+	CapMacOverride:       "cap_mac_override",
+	CapMacAdmin:          "cap_mac_admin",
+	CapSyslog:            "cap_syslog",
+	CapWakeAlarm:         "cap_wake_alarm",
+	CapBlockSuspend:      "cap_block_suspend",
+	CapAuditRead:         "cap_audit_read",
+	CapPerfmon:           "cap_perfmon",
+	CapBpf:               "cap_bpf",
+	CapCheckpointRestore: "cap_checkpoint_restore",
+}
+
+// String returns the name of the capability as used by libcap, e.g.
+// cap_sys_admin. Capabilities unknown to this package are named by number.
+func (c Capability) String() string {
+	if int(c) < len(capabilityNames) {
+		return capabilityNames[c]
+	}
+
+	return fmt.Sprintf("cap_%d", uint(c))
+}
+
+// CapabilitySet is a set of capabilities, decoded from the bitmasks in
+// /proc/[pid]/status.
+type CapabilitySet uint64
+
+// Has returns whether c is part of the set.
+func (s CapabilitySet) Has(c Capability) bool {
+	return c < 64 && s&(1<<c) != 0
+}
+
+// Capabilities returns the capabilities in the set, in ascending order.
+func (s CapabilitySet) Capabilities() []Capability {
+	var caps []Capability
+	for c := Capability(0); c < 64; c++ {
+		if s.Has(c) {
+			caps = append(caps, c)
+		}
+	}
+
+	return caps
+}
+
+// String returns the comma separated names of the capabilities in the set.
+func (s CapabilitySet) String() string {
+	caps := s.Capabilities()
+	names := make([]string, 0, len(caps))
+	for _, c := range caps {
+		names = append(names, c.String())
+	}
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Cgroup models one line of /proc/[pid]/cgroup, the membership of a process
+// in a single cgroup hierarchy.
+type Cgroup struct {
+	// ID of the hierarchy, 0 for the unified cgroup v2 hierarchy.
+	HierarchyID int
+	// Controllers bound to the hierarchy, empty for cgroup v2.
+	Controllers []string
+	// Path of the cgroup relative to the mount point of the hierarchy.
+	Path string
+}
+
+// Cgroups returns the cgroups the process is a member of, read from
+// /proc/[pid]/cgroup.
+func (p Proc) Cgroups() ([]Cgroup, error) {
+	data, err := p.readFile("cgroup")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCgroups(bytes.NewReader(data))
+}
+
+func parseCgroups(r io.Reader) ([]Cgroup, error) {
+	var (
+		cgroups []Cgroup
+		s       = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		// The path itself may contain colons.
+		parts := strings.SplitN(s.Text(), ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid cgroup line: %q", s.Text())
+		}
+
+		id, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (cgroup hierarchy ID): %s", parts[0], err)
+		}
+
+		var controllers []string
+		if parts[1] != "" {
+			controllers = strings.Split(parts[1], ",")
+		}
+
+		cgroups = append(cgroups, Cgroup{
+			HierarchyID: id,
+			Controllers: controllers,
+			Path:        parts[2],
+		})
+	}
+
+	return cgroups, s.Err()
+}
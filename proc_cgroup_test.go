@@ -0,0 +1,57 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProcCgroups(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(30004)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cgroups, err := p.Cgroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Cgroup{
+		{HierarchyID: 12, Controllers: []string{"pids"}, Path: "/system.slice/logrotate.service"},
+		{HierarchyID: 11, Controllers: []string{"cpu", "cpuacct"}, Path: "/system.slice/logrotate.service"},
+		{HierarchyID: 0, Path: "/system.slice/logrotate.service"},
+	}
+	if !reflect.DeepEqual(want, cgroups) {
+		t.Errorf("want %+v, have %+v", want, cgroups)
+	}
+}
+
+func TestParseCgroups(t *testing.T) {
+	cgroups, err := parseCgroups(strings.NewReader("0::/user.slice/odd:name\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/user.slice/odd:name", cgroups[0].Path; want != have {
+		t.Errorf("want path %s, have %s", want, have)
+	}
+
+	for _, s := range []string{"0:/no/controllers\n", "x::/\n"} {
+		if _, err := parseCgroups(strings.NewReader(s)); err == nil {
+			t.Errorf("%q: want an error, have none", s)
+		}
+	}
+}
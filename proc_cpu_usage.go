@@ -0,0 +1,78 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+)
+
+// ProcCPUUsage holds the CPU utilization of a process between two samples,
+// in percent.
+type ProcCPUUsage struct {
+	// Utilization relative to wall-clock time, where 100 means one CPU was
+	// used for the whole interval. Multi-threaded processes can exceed 100.
+	User   float64
+	System float64
+	Total  float64
+
+	// Utilization relative to the CPU time of all CPUs of the host, where
+	// 100 means all CPUs were used by the process for the whole interval.
+	HostUser   float64
+	HostSystem float64
+	HostTotal  float64
+}
+
+// NewProcCPUUsage computes the CPU utilization of a process from two samples
+// of its stat, prev and cur, and the samples of the host stat, prevHost and
+// curHost, read at the same times. The wall-clock time between the samples is
+// derived from the host CPU time. ErrPIDReused is returned if the samples
+// belong to different processes.
+func NewProcCPUUsage(prev, cur ProcStat, prevHost, curHost Stat) (ProcCPUUsage, error) {
+	if prev.ID() != cur.ID() {
+		return ProcCPUUsage{}, ErrPIDReused
+	}
+	if cur.UTime < prev.UTime || cur.STime < prev.STime {
+		return ProcCPUUsage{}, fmt.Errorf("CPU time of process %d went backwards", cur.PID)
+	}
+
+	hostTime := curHost.CPUTotal.total() - prevHost.CPUTotal.total()
+	if hostTime <= 0 {
+		return ProcCPUUsage{}, fmt.Errorf("no host CPU time elapsed between samples")
+	}
+	numCPU := len(curHost.CPU)
+	if numCPU == 0 {
+		numCPU = 1
+	}
+	wallTime := hostTime / float64(numCPU)
+
+	var (
+		user   = float64(cur.UTime-prev.UTime) / userHZ
+		system = float64(cur.STime-prev.STime) / userHZ
+	)
+
+	return ProcCPUUsage{
+		User:       100 * user / wallTime,
+		System:     100 * system / wallTime,
+		Total:      100 * (user + system) / wallTime,
+		HostUser:   100 * user / hostTime,
+		HostSystem: 100 * system / hostTime,
+		HostTotal:  100 * (user + system) / hostTime,
+	}, nil
+}
+
+// total returns the CPU time spent in all states. Guest time is already
+// accounted for in user and nice time.
+func (s CPUStat) total() float64 {
+	return s.User + s.Nice + s.System + s.Idle + s.Iowait + s.IRQ + s.SoftIRQ + s.Steal
+}
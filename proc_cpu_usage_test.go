@@ -0,0 +1,82 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewProcCPUUsage(t *testing.T) {
+	// Two CPUs over 10 seconds.
+	prevHost := Stat{
+		CPUTotal: CPUStat{User: 100, System: 50, Idle: 1000, Guest: 20},
+		CPU:      make([]CPUStat, 2),
+	}
+	curHost := Stat{
+		CPUTotal: CPUStat{User: 106, System: 54, Idle: 1010, Guest: 25},
+		CPU:      make([]CPUStat, 2),
+	}
+	prev := ProcStat{PID: 42, Starttime: 1000, UTime: 100, STime: 50}
+	cur := ProcStat{PID: 42, Starttime: 1000, UTime: 550, STime: 250}
+
+	usage, err := NewProcCPUUsage(prev, cur, prevHost, curHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ProcCPUUsage{
+		User:       45,
+		System:     20,
+		Total:      65,
+		HostUser:   22.5,
+		HostSystem: 10,
+		HostTotal:  32.5,
+	}
+	for _, test := range []struct {
+		name       string
+		want, have float64
+	}{
+		{name: "user", want: want.User, have: usage.User},
+		{name: "system", want: want.System, have: usage.System},
+		{name: "total", want: want.Total, have: usage.Total},
+		{name: "host user", want: want.HostUser, have: usage.HostUser},
+		{name: "host system", want: want.HostSystem, have: usage.HostSystem},
+		{name: "host total", want: want.HostTotal, have: usage.HostTotal},
+	} {
+		if math.Abs(test.want-test.have) > 1e-9 {
+			t.Errorf("want %s %f, have %f", test.name, test.want, test.have)
+		}
+	}
+}
+
+func TestNewProcCPUUsageErrors(t *testing.T) {
+	host := Stat{CPUTotal: CPUStat{Idle: 10}}
+	laterHost := Stat{CPUTotal: CPUStat{Idle: 20}}
+	prev := ProcStat{PID: 42, Starttime: 1000, UTime: 100}
+
+	reused := ProcStat{PID: 42, Starttime: 2000, UTime: 10}
+	if _, err := NewProcCPUUsage(prev, reused, host, laterHost); err != ErrPIDReused {
+		t.Errorf("want ErrPIDReused, have %v", err)
+	}
+
+	if _, err := NewProcCPUUsage(prev, prev, host, host); err == nil {
+		t.Error("want an error without elapsed host time")
+	}
+
+	backwards := ProcStat{PID: 42, Starttime: 1000, UTime: 50}
+	if _, err := NewProcCPUUsage(prev, backwards, host, laterHost); err == nil {
+		t.Error("want an error for CPU time going backwards")
+	}
+}
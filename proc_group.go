@@ -0,0 +1,183 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"os"
+	"sort"
+)
+
+// ProcNamer returns the name of the group a process belongs to, or the empty
+// string if the process doesn't belong to any group.
+type ProcNamer func(Proc) (string, error)
+
+// NamedProcMatcher assigns the processes selected by Matcher to the group
+// Name.
+type NamedProcMatcher struct {
+	Name    string
+	Matcher ProcMatcher
+}
+
+// NameByMatchers returns a ProcNamer assigning processes to the group of the
+// first matcher selecting them. As with MatchAny, an error of one matcher is
+// only returned if no other matcher selects the process.
+func NameByMatchers(matchers ...NamedProcMatcher) ProcNamer {
+	return func(p Proc) (string, error) {
+		var firstErr error
+		for _, m := range matchers {
+			ok, err := m.Matcher.Match(p)
+			if ok && err == nil {
+				return m.Name, nil
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return "", firstErr
+	}
+}
+
+// ProcGroup holds the statistics of a group of processes, summed up over the
+// members of the group.
+type ProcGroup struct {
+	// Name of the group.
+	Name string
+	// Members of the group, sorted by PID.
+	Procs Procs
+
+	// Number of threads.
+	NumThreads int
+	// Time scheduled in user mode, in seconds.
+	UserTime float64
+	// Time scheduled in kernel mode, in seconds.
+	SystemTime float64
+	// Number of minor page faults.
+	MinFlt uint64
+	// Number of major page faults.
+	MajFlt uint64
+	// Virtual memory size in bytes.
+	VirtualMemory uint64
+	// Resident memory size in bytes.
+	ResidentMemory uint64
+	// Swapped out memory in bytes.
+	VmSwap uint64
+	// Number of voluntary context switches.
+	VoluntaryCtxtSwitches uint64
+	// Number of involuntary context switches.
+	NonVoluntaryCtxtSwitches uint64
+	// Bytes read from storage, from the members whose I/O statistics could
+	// be read.
+	ReadBytes uint64
+	// Bytes written to storage, from the members whose I/O statistics could
+	// be read.
+	WriteBytes uint64
+}
+
+// ProcGrouper assigns processes to groups and aggregates their statistics.
+// Once assigned, a process stays in its group for as long as it lives, even
+// if it no longer matches, e.g. after changing its command line.
+type ProcGrouper struct {
+	namer   ProcNamer
+	members map[ProcID]string
+}
+
+// NewProcGrouper returns a ProcGrouper assigning processes to groups with
+// namer.
+func NewProcGrouper(namer ProcNamer) *ProcGrouper {
+	return &ProcGrouper{
+		namer:   namer,
+		members: map[ProcID]string{},
+	}
+}
+
+// Group assigns the processes in procs to groups and returns the statistics
+// of each group, keyed by group name. Processes that exit while being read,
+// or whose group can't be determined with the current privileges, are left
+// out. Group is meant to be called repeatedly, e.g. on every scrape, and
+// forgets about the processes that are no longer in procs.
+func (g *ProcGrouper) Group(procs Procs) (map[string]ProcGroup, error) {
+	var (
+		groups  = map[string]ProcGroup{}
+		members = make(map[ProcID]string, len(g.members))
+	)
+
+	for _, p := range procs {
+		stat, err := p.NewStat()
+		if isProcGone(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		id := stat.ID()
+		name, ok := g.members[id]
+		if !ok {
+			name, err = g.namer(p)
+			if isProcGone(err) || os.IsPermission(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if name == "" {
+			continue
+		}
+
+		status, err := p.NewStatus()
+		if isProcGone(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// I/O statistics need privileges and kernel support, members
+		// without them are counted without I/O.
+		pio, err := p.NewIO()
+		if err != nil && !isProcGone(err) && !os.IsPermission(err) {
+			return nil, err
+		}
+
+		members[id] = name
+		group := groups[name]
+		group.Name = name
+		group.Procs = append(group.Procs, p)
+		group.add(stat, status, pio)
+		groups[name] = group
+	}
+
+	g.members = members
+	for name, group := range groups {
+		sort.Sort(group.Procs)
+		groups[name] = group
+	}
+
+	return groups, nil
+}
+
+func (g *ProcGroup) add(stat ProcStat, status ProcStatus, pio ProcIO) {
+	g.NumThreads += stat.NumThreads
+	g.UserTime += float64(stat.UTime) / userHZ
+	g.SystemTime += float64(stat.STime) / userHZ
+	g.MinFlt += uint64(stat.MinFlt)
+	g.MajFlt += uint64(stat.MajFlt)
+	g.VirtualMemory += uint64(stat.VirtualMemory())
+	g.ResidentMemory += uint64(stat.ResidentMemory())
+	g.VmSwap += status.VmSwap
+	g.VoluntaryCtxtSwitches += status.VoluntaryCtxtSwitches
+	g.NonVoluntaryCtxtSwitches += status.NonVoluntaryCtxtSwitches
+	g.ReadBytes += pio.ReadBytes
+	g.WriteBytes += pio.WriteBytes
+}
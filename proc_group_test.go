@@ -0,0 +1,109 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestProcGrouper(t *testing.T) {
+	fs := getProcFixtures(t)
+	procs, err := fs.AllProcs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewProcGrouper(NameByMatchers(
+		NamedProcMatcher{Name: "nginx", Matcher: MatchCgroup(regexp.MustCompile(`/nginx\.service$`))},
+		NamedProcMatcher{Name: "editors", Matcher: MatchComm("vim")},
+	))
+
+	groups, err := g.Group(procs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(groups); want != have {
+		t.Fatalf("want %d groups, have %d", want, have)
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	want := ProcGroup{
+		Name:                     "nginx",
+		Procs:                    Procs{{PID: 30001, fs: fs.proc}, {PID: 30002, fs: fs.proc}, {PID: 30003, fs: fs.proc}},
+		NumThreads:               5,
+		UserTime:                 18.7,
+		SystemTime:               5.9,
+		MinFlt:                   3 * 1520,
+		VirtualMemory:            3 * 52428800,
+		ResidentMemory:           (2048 + 4096 + 3072) * pageSize,
+		VmSwap:                   (128 + 64) * 1024,
+		VoluntaryCtxtSwitches:    150 + 4000 + 3500,
+		NonVoluntaryCtxtSwitches: 10 + 200 + 150,
+		ReadBytes:                4096,
+		WriteBytes:               8192 + 4096,
+	}
+	if have := groups["nginx"]; !reflect.DeepEqual(want, have) {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+
+	editors := groups["editors"]
+	if want, have := 1, len(editors.Procs); want != have || editors.Procs[0].PID != 26231 {
+		t.Errorf("want editors group with 26231, have %v", editors.Procs)
+	}
+}
+
+func TestProcGrouperStableMembership(t *testing.T) {
+	procs, err := getProcFixtures(t).AllProcs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[int]string{30002: "web"}
+	g := NewProcGrouper(func(p Proc) (string, error) {
+		return names[p.PID], nil
+	})
+
+	if _, err := g.Group(procs); err != nil {
+		t.Fatal(err)
+	}
+
+	// The process keeps its group even though it no longer matches, and
+	// processes newly matching join their group.
+	names = map[int]string{30002: "other", 30003: "web"}
+	groups, err := g.Group(procs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(groups["web"].Procs); want != have {
+		t.Errorf("want %d processes in group web, have %d", want, have)
+	}
+	if _, ok := groups["other"]; ok {
+		t.Error("want no group other")
+	}
+
+	// Processes that are gone are forgotten.
+	if _, err := g.Group(Procs{}); err != nil {
+		t.Fatal(err)
+	}
+	groups, err = g.Group(procs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, len(groups["other"].Procs); want != have {
+		t.Errorf("want %d processes in group other, have %d", want, have)
+	}
+}
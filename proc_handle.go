@@ -0,0 +1,126 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"syscall"
+)
+
+// ErrProcGone is returned by the methods of a ProcHandle when the process has
+// exited.
+var ErrProcGone = errors.New("process has exited")
+
+// ProcHandle is a Proc that reads all files of the process relative to
+// /proc/[pid], opened once as a directory when the handle is created. Once the
+// process exits, reads fail with ErrProcGone, even if its PID is reused in the
+// meantime, so several files read through the handle always describe the same
+// process. The files under /proc/[pid]/net are still read by path.
+//
+// Handles are only supported on Linux and must be closed after use. Procs
+// taken from a handle, like copies of its Proc field, share its directory and
+// are only valid until the handle is closed.
+type ProcHandle struct {
+	Proc
+}
+
+// OpenHandle opens a handle of the process. An error is returned on
+// platforms without support for handles.
+func (p Proc) OpenHandle() (*ProcHandle, error) {
+	d, err := os.Open(p.path())
+	if err != nil {
+		return nil, err
+	}
+
+	h := &ProcHandle{Proc: p}
+	h.dir = d
+
+	// Reading a file relative to the directory fails right away on
+	// platforms without openat(2), rather than on every later read.
+	f, err := openat(d, "stat", os.O_RDONLY)
+	if err != nil {
+		err = h.handleErr(err)
+		d.Close()
+		return nil, err
+	}
+	f.Close()
+
+	return h, nil
+}
+
+// Close releases the directory of the process. The handle, and the Procs
+// taken from it, must not be used afterwards.
+func (h *ProcHandle) Close() error {
+	err := h.dir.Close()
+	h.dir = nil
+	return err
+}
+
+// readFileAt reads the file at path name relative to the directory of the
+// process.
+func (p Proc) readFileAt(name string) ([]byte, error) {
+	f, err := openat(p.dir, name, os.O_RDONLY)
+	if err != nil {
+		return nil, p.handleErr(err)
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, p.handleErr(err)
+	}
+
+	return data, nil
+}
+
+// writeFileAt writes data to the file at path name relative to the directory
+// of the process.
+func (p Proc) writeFileAt(name string, data []byte) error {
+	f, err := openat(p.dir, name, os.O_WRONLY|os.O_TRUNC)
+	if err != nil {
+		return p.handleErr(err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return p.handleErr(err)
+	}
+
+	return f.Close()
+}
+
+// handleErr replaces errors caused by the process having exited with
+// ErrProcGone. The files of an exited process vanish, so a missing file is
+// only attributed to the process exiting if its stat file is gone as well.
+func (p Proc) handleErr(err error) error {
+	pe, ok := err.(*os.PathError)
+	if !ok {
+		return err
+	}
+
+	switch pe.Err {
+	case syscall.ESRCH:
+		return ErrProcGone
+	case syscall.ENOENT:
+		f, statErr := openat(p.dir, "stat", os.O_RDONLY)
+		if statErr != nil {
+			return ErrProcGone
+		}
+		f.Close()
+	}
+
+	return err
+}
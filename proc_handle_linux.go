@@ -0,0 +1,66 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package procfs
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// openat opens the file at path name relative to the directory dir, with the
+// os.O_* flags flag.
+func openat(dir *os.File, name string, flag int) (*os.File, error) {
+	path := filepath.Join(dir.Name(), name)
+
+	fd, err := syscall.Openat(int(dir.Fd()), name, flag|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: path, Err: err}
+	}
+
+	return os.NewFile(uintptr(fd), path), nil
+}
+
+// readlinkat returns the target of the symbolic link at path name relative to
+// the directory dir. The syscall package doesn't export readlinkat.
+func readlinkat(dir *os.File, name string) (string, error) {
+	path := filepath.Join(dir.Name(), name)
+
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return "", &os.PathError{Op: "readlinkat", Path: path, Err: err}
+	}
+
+	for size := 128; ; size *= 2 {
+		b := make([]byte, size)
+		n, _, errno := syscall.Syscall6(
+			syscall.SYS_READLINKAT,
+			dir.Fd(),
+			uintptr(unsafe.Pointer(p)),
+			uintptr(unsafe.Pointer(&b[0])),
+			uintptr(len(b)),
+			0, 0,
+		)
+		if errno != 0 {
+			return "", &os.PathError{Op: "readlinkat", Path: path, Err: errno}
+		}
+		// A full buffer might have truncated the target.
+		if int(n) < size {
+			return string(b[:n]), nil
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestProcHandle(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := p.OpenHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	for _, test := range []struct {
+		name string
+		read func(Proc) (interface{}, error)
+	}{
+		{name: "stat", read: func(p Proc) (interface{}, error) {
+			s, err := p.NewStat()
+			s.proc = ""
+			return s, err
+		}},
+		{name: "status", read: func(p Proc) (interface{}, error) { return p.NewStatus() }},
+		{name: "io", read: func(p Proc) (interface{}, error) { return p.NewIO() }},
+		{name: "limits", read: func(p Proc) (interface{}, error) { return p.NewLimits() }},
+		{name: "cmdline", read: func(p Proc) (interface{}, error) { return p.CmdLine() }},
+		{name: "comm", read: func(p Proc) (interface{}, error) { return p.Comm() }},
+		{name: "exe", read: func(p Proc) (interface{}, error) { return p.Executable() }},
+		{name: "namespaces", read: func(p Proc) (interface{}, error) { return p.NewNamespaces() }},
+		{name: "fd targets", read: func(p Proc) (interface{}, error) { return p.FileDescriptorTargets() }},
+	} {
+		want, err := test.read(p)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		have, err := test.read(h.Proc)
+		if err != nil {
+			t.Fatalf("%s through handle: %s", test.name, err)
+		}
+		if !reflect.DeepEqual(want, have) {
+			t.Errorf("%s: want %+v, have %+v", test.name, want, have)
+		}
+	}
+
+	// A file that is missing while the process is still there isn't
+	// reported as the process being gone.
+	if _, err := h.Cgroups(); err == ErrProcGone || !os.IsNotExist(err) {
+		t.Errorf("want a not exist error, have %v", err)
+	}
+}
+
+func TestProcHandleGone(t *testing.T) {
+	files := map[string]string{
+		"stat": "100 (sleep) S 1 100 100 0 -1 4194560 100 0 0 0 0 0 0 0 20 0 1 0 5000 1000 100",
+	}
+	p, cleanup := getTempProc(t, 100, files)
+	defer cleanup()
+
+	h, err := p.OpenHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if _, err := h.NewStat(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replacing the directory mimics the PID being reused.
+	if err := os.RemoveAll(p.path()); err != nil {
+		t.Fatal(err)
+	}
+	writeProcDir(t, p.path(), files)
+
+	if _, err := h.NewStat(); err != ErrProcGone {
+		t.Errorf("want ErrProcGone, have %v", err)
+	}
+	if _, err := h.Executable(); err != ErrProcGone {
+		t.Errorf("want ErrProcGone, have %v", err)
+	}
+	if _, err := p.NewStat(); err != nil {
+		t.Errorf("want the new process to be read by path, have %v", err)
+	}
+}
+
+func TestProcHandleWrite(t *testing.T) {
+	p, cleanup := getTempProc(t, 100, map[string]string{
+		"stat":          "100 (sleep) S 1 100 100 0 -1 4194560 100 0 0 0 0 0 0 0 20 0 1 0 5000 1000 100",
+		"oom_score_adj": "-1000\n",
+	})
+	defer cleanup()
+
+	h, err := p.OpenHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if err := h.SetOOMScoreAdj(7); err != nil {
+		t.Fatal(err)
+	}
+	adj, err := h.OOMScoreAdj()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 7, adj; want != have {
+		t.Errorf("want oom_score_adj %d, have %d", want, have)
+	}
+}
+
+func TestProcHandleExited(t *testing.T) {
+	fs, err := NewFS(DefaultMountPoint)
+	if err != nil {
+		t.Skip("no proc filesystem available")
+	}
+
+	cmd := exec.Command("sleep", "60")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("couldn't start process: %s", err)
+	}
+	p, err := fs.NewProc(cmd.Process.Pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := p.OpenHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if _, err := h.NewStat(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd.Process.Kill()
+	cmd.Wait()
+
+	if _, err := h.NewStat(); err != ErrProcGone {
+		t.Errorf("want ErrProcGone, have %v", err)
+	}
+	if _, err := h.FileDescriptors(); err != ErrProcGone {
+		t.Errorf("want ErrProcGone, have %v", err)
+	}
+}
+
+func TestProcHandleClose(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := p.OpenHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if h.dir != nil {
+		t.Error("want the directory to be released on close")
+	}
+}
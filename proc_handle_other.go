@@ -0,0 +1,31 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package procfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// openat always fails on platforms without openat(2).
+func openat(dir *os.File, name string, flag int) (*os.File, error) {
+	return nil, fmt.Errorf("not supported on this platform")
+}
+
+// readlinkat always fails on platforms without readlinkat(2).
+func readlinkat(dir *os.File, name string) (string, error) {
+	return "", fmt.Errorf("not supported on this platform")
+}
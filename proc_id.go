@@ -0,0 +1,90 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPIDReused is returned when a PID no longer refers to the expected
+// process, because the kernel reused it for a new one.
+var ErrPIDReused = errors.New("PID was reused by a different process")
+
+// ProcID identifies a process across PID reuse. The kernel recycles PIDs, but
+// no two processes with the same PID start in the same clock tick.
+type ProcID struct {
+	// The process ID.
+	PID int
+	// The time the process started after system boot, in clock ticks.
+	Starttime uint64
+}
+
+// String returns the ID in the form pid/starttime.
+func (id ProcID) String() string {
+	return fmt.Sprintf("%d/%d", id.PID, id.Starttime)
+}
+
+// ID returns the identity of the process the stat was read from.
+func (s ProcStat) ID() ProcID {
+	return ProcID{PID: s.PID, Starttime: s.Starttime}
+}
+
+// ID returns the identity of the process currently running with the PID of p.
+func (p Proc) ID() (ProcID, error) {
+	stat, err := p.NewStat()
+	if err != nil {
+		return ProcID{}, err
+	}
+
+	return stat.ID(), nil
+}
+
+// Is returns whether p still refers to the process identified by id. It is
+// false if the process exited, or if its PID was reused by another process.
+func (p Proc) Is(id ProcID) (bool, error) {
+	if p.PID != id.PID {
+		return false, nil
+	}
+
+	current, err := p.ID()
+	if isProcGone(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return current == id, nil
+}
+
+// NewProcByID returns the process identified by id. ErrPIDReused is returned
+// if the PID now belongs to another process, and an error satisfying
+// os.IsNotExist if no process with the PID exists.
+func (fs FS) NewProcByID(id ProcID) (Proc, error) {
+	p, err := fs.NewProc(id.PID)
+	if err != nil {
+		return Proc{}, err
+	}
+
+	current, err := p.ID()
+	if err != nil {
+		return Proc{}, err
+	}
+	if current != id {
+		return Proc{}, ErrPIDReused
+	}
+
+	return p, nil
+}
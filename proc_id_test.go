@@ -0,0 +1,84 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"os"
+	"testing"
+)
+
+func TestProcID(t *testing.T) {
+	fs := getProcFixtures(t)
+	p, err := fs.NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := p.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (ProcID{PID: 26231, Starttime: 82375}), id; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "26231/82375", id.String(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+
+	for _, test := range []struct {
+		name string
+		id   ProcID
+		want bool
+	}{
+		{name: "same process", id: id, want: true},
+		{name: "reused PID", id: ProcID{PID: 26231, Starttime: 1}, want: false},
+		{name: "other PID", id: ProcID{PID: 26232, Starttime: 82375}, want: false},
+	} {
+		have, err := p.Is(test.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.want != have {
+			t.Errorf("%s: want %t, have %t", test.name, test.want, have)
+		}
+	}
+
+	// 26233 has no stat file, as if it exited.
+	gone, err := fs.NewProc(26233)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := gone.Is(ProcID{PID: 26233, Starttime: 1}); ok || err != nil {
+		t.Errorf("want exited process not to match, have %t, %v", ok, err)
+	}
+}
+
+func TestNewProcByID(t *testing.T) {
+	fs := getProcFixtures(t)
+
+	p, err := fs.NewProcByID(ProcID{PID: 26231, Starttime: 82375})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 26231, p.PID; want != have {
+		t.Errorf("want PID %d, have %d", want, have)
+	}
+
+	if _, err := fs.NewProcByID(ProcID{PID: 26231, Starttime: 1}); err != ErrPIDReused {
+		t.Errorf("want ErrPIDReused, have %v", err)
+	}
+	if _, err := fs.NewProcByID(ProcID{PID: 99999, Starttime: 1}); !os.IsNotExist(err) {
+		t.Errorf("want a not exist error, have %v", err)
+	}
+}
@@ -15,8 +15,6 @@ package procfs
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
 )
 
 // ProcIO models the content of /proc/<pid>/io.
@@ -43,13 +41,7 @@ type ProcIO struct {
 func (p Proc) NewIO() (ProcIO, error) {
 	pio := ProcIO{}
 
-	f, err := os.Open(p.path("io"))
-	if err != nil {
-		return pio, err
-	}
-	defer f.Close()
-
-	data, err := ioutil.ReadAll(f)
+	data, err := p.readFile("io")
 	if err != nil {
 		return pio, err
 	}
@@ -15,8 +15,8 @@ package procfs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
-	"os"
 	"regexp"
 	"strconv"
 )
@@ -79,21 +79,20 @@ var (
 
 // NewLimits returns the current soft limits of the process.
 func (p Proc) NewLimits() (ProcLimits, error) {
-	f, err := os.Open(p.path("limits"))
+	data, err := p.readFile("limits")
 	if err != nil {
 		return ProcLimits{}, err
 	}
-	defer f.Close()
 
 	var (
 		l = ProcLimits{}
-		s = bufio.NewScanner(f)
+		s = bufio.NewScanner(bytes.NewReader(data))
 	)
 	for s.Scan() {
 		fields := limitsDelimiter.Split(s.Text(), limitsFields)
 		if len(fields) != limitsFields {
 			return ProcLimits{}, fmt.Errorf(
-				"couldn't parse %s line %s", f.Name(), s.Text())
+				"couldn't parse %s line %s", p.path("limits"), s.Text())
 		}
 
 		switch fields[0] {
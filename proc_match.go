@@ -0,0 +1,177 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ProcMatcher selects processes, e.g. to filter Procs.
+type ProcMatcher interface {
+	// Match returns whether the process is selected.
+	Match(Proc) (bool, error)
+}
+
+// ProcMatcherFunc adapts a function to a ProcMatcher.
+type ProcMatcherFunc func(Proc) (bool, error)
+
+// Match calls f(p).
+func (f ProcMatcherFunc) Match(p Proc) (bool, error) {
+	return f(p)
+}
+
+// MatchComm selects processes whose command name, as read from
+// /proc/[pid]/comm, is one of names.
+func MatchComm(names ...string) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		comm, err := p.Comm()
+		if err != nil {
+			return false, err
+		}
+		return containsString(names, comm), nil
+	})
+}
+
+// MatchExe selects processes whose executable is one of paths. Paths without
+// a slash are compared to the base name of the executable only.
+func MatchExe(paths ...string) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		exe, err := p.Executable()
+		if err != nil || exe == "" {
+			return false, err
+		}
+		for _, path := range paths {
+			if path == exe || !strings.Contains(path, "/") && path == exe[strings.LastIndex(exe, "/")+1:] {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// MatchCmdline selects processes whose command line, with the arguments
+// joined by spaces, matches re.
+func MatchCmdline(re *regexp.Regexp) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		cmdline, err := p.CmdLine()
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(strings.Join(cmdline, " ")), nil
+	})
+}
+
+// MatchUser selects processes whose effective user ID is one of uids.
+func MatchUser(uids ...uint64) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		status, err := p.NewStatus()
+		if err != nil {
+			return false, err
+		}
+		for _, uid := range uids {
+			if status.UIDs[1] == uid {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// MatchCgroup selects processes that are a member of a cgroup whose path
+// matches re, in any hierarchy.
+func MatchCgroup(re *regexp.Regexp) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		cgroups, err := p.Cgroups()
+		if err != nil {
+			return false, err
+		}
+		for _, cg := range cgroups {
+			if re.MatchString(cg.Path) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// MatchAll selects processes selected by all of matchers.
+func MatchAll(matchers ...ProcMatcher) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		for _, m := range matchers {
+			if ok, err := m.Match(p); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+}
+
+// MatchAny selects processes selected by at least one of matchers. An error
+// of one matcher is only returned if no other matcher selects the process.
+func MatchAny(matchers ...ProcMatcher) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		var firstErr error
+		for _, m := range matchers {
+			ok, err := m.Match(p)
+			if ok && err == nil {
+				return true, nil
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return false, firstErr
+	})
+}
+
+// MatchNot selects processes not selected by m.
+func MatchNot(m ProcMatcher) ProcMatcher {
+	return ProcMatcherFunc(func(p Proc) (bool, error) {
+		ok, err := m.Match(p)
+		return !ok && err == nil, err
+	})
+}
+
+// Filter returns the processes of p selected by m. Processes that exit while
+// being matched, or whose files can't be read with the current privileges,
+// are left out.
+func (p Procs) Filter(m ProcMatcher) (Procs, error) {
+	filtered := Procs{}
+	for _, proc := range p {
+		ok, err := m.Match(proc)
+		if isProcGone(err) || os.IsPermission(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, proc)
+		}
+	}
+
+	return filtered, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func TestProcsFilter(t *testing.T) {
+	procs, err := getProcFixtures(t).AllProcs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nginx := MatchCgroup(regexp.MustCompile(`/nginx\.service$`))
+	for _, test := range []struct {
+		name    string
+		matcher ProcMatcher
+		want    []int
+	}{
+		{name: "comm", matcher: MatchComm("vim", "bash"), want: []int{26231}},
+		{name: "exe base name", matcher: MatchExe("vim"), want: []int{26231}},
+		{name: "exe path", matcher: MatchExe("/usr/bin/vim"), want: []int{26231}},
+		{name: "other exe path", matcher: MatchExe("/bin/vim"), want: []int{}},
+		{name: "cmdline", matcher: MatchCmdline(regexp.MustCompile(`^nginx: worker`)), want: []int{30002, 30003}},
+		{name: "user", matcher: MatchUser(33), want: []int{30002, 30003}},
+		{name: "cgroup", matcher: nginx, want: []int{30001, 30002, 30003}},
+		{name: "all", matcher: MatchAll(nginx, MatchUser(0)), want: []int{30001}},
+		{name: "any", matcher: MatchAny(MatchComm("vim"), MatchUser(33)), want: []int{26231, 30002, 30003}},
+		{name: "not", matcher: MatchAll(nginx, MatchNot(MatchUser(0))), want: []int{30002, 30003}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, err := procs.Filter(test.matcher)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Sort(filtered)
+
+			have := []int{}
+			for _, p := range filtered {
+				have = append(have, p.PID)
+			}
+			if !reflect.DeepEqual(test.want, have) {
+				t.Errorf("want %v, have %v", test.want, have)
+			}
+		})
+	}
+}
@@ -15,7 +15,6 @@ package procfs
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 )
@@ -32,20 +31,14 @@ type Namespaces map[string]Namespace
 // NewNamespaces reads from /proc/[pid/ns/* to get the namespaces of which the
 // process is a member.
 func (p Proc) NewNamespaces() (Namespaces, error) {
-	d, err := os.Open(p.path("ns"))
+	names, err := p.readDirNames("ns")
 	if err != nil {
 		return nil, err
 	}
-	defer d.Close()
-
-	names, err := d.Readdirnames(-1)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read contents of ns dir: %v", err)
-	}
 
 	ns := make(Namespaces, len(names))
 	for _, name := range names {
-		target, err := os.Readlink(p.path("ns", name))
+		target, err := p.readlink("ns", name)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,72 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Bounds of the values of /proc/[pid]/oom_score_adj.
+const (
+	OOMScoreAdjMin = -1000
+	OOMScoreAdjMax = 1000
+)
+
+// OOMScore returns the badness score of the process used by the OOM killer,
+// read from /proc/[pid]/oom_score. The process with the highest score is
+// killed first.
+func (p Proc) OOMScore() (int, error) {
+	return p.readIntFile("oom_score")
+}
+
+// OOMScoreAdj returns the adjustment of the OOM badness score of the process,
+// read from /proc/[pid]/oom_score_adj. It ranges from OOMScoreAdjMin, which
+// disables OOM killing of the process, to OOMScoreAdjMax.
+func (p Proc) OOMScoreAdj() (int, error) {
+	return p.readIntFile("oom_score_adj")
+}
+
+// SetOOMScoreAdj writes the adjustment of the OOM badness score of the
+// process to /proc/[pid]/oom_score_adj. Lowering it below the value last set
+// by an unprivileged process requires CAP_SYS_RESOURCE, a PermissionError is
+// returned without it.
+func (p Proc) SetOOMScoreAdj(adj int) error {
+	if adj < OOMScoreAdjMin || adj > OOMScoreAdjMax {
+		return fmt.Errorf("oom_score_adj %d out of range [%d, %d]", adj, OOMScoreAdjMin, OOMScoreAdjMax)
+	}
+
+	err := p.writeFile([]byte(strconv.Itoa(adj)), "oom_score_adj")
+	if os.IsPermission(err) {
+		return &PermissionError{PID: p.PID, File: "oom_score_adj", Err: err}
+	}
+
+	return err
+}
+
+func (p Proc) readIntFile(name string) (int, error) {
+	data, err := p.readFile(name)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse %s (%s): %s", data, name, err)
+	}
+
+	return v, nil
+}
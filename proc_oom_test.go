@@ -0,0 +1,65 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import "testing"
+
+func TestOOMScore(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	score, err := p.OOMScore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 667, score; want != have {
+		t.Errorf("want oom_score %d, have %d", want, have)
+	}
+
+	adj, err := p.OOMScoreAdj()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 300, adj; want != have {
+		t.Errorf("want oom_score_adj %d, have %d", want, have)
+	}
+}
+
+func TestSetOOMScoreAdj(t *testing.T) {
+	p, cleanup := getTempProc(t, 42, map[string]string{"oom_score_adj": "0\n"})
+	defer cleanup()
+
+	// Values longer and shorter than the previous one must both replace it
+	// entirely.
+	for _, want := range []int{-500, 5, -1000} {
+		if err := p.SetOOMScoreAdj(want); err != nil {
+			t.Fatal(err)
+		}
+		have, err := p.OOMScoreAdj()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want != have {
+			t.Errorf("want oom_score_adj %d, have %d", want, have)
+		}
+	}
+
+	for _, adj := range []int{OOMScoreAdjMin - 1, OOMScoreAdjMax + 1} {
+		if err := p.SetOOMScoreAdj(adj); err == nil {
+			t.Errorf("want an error for oom_score_adj %d", adj)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Wchan returns the name of the kernel function the process is sleeping in,
+// read from /proc/[pid]/wchan, or the empty string if the process isn't
+// sleeping. Without ptrace access to the process, the kernel reports it as
+// not sleeping.
+func (p Proc) Wchan() (string, error) {
+	data, err := p.readPrivilegedFile("wchan")
+	if err != nil {
+		return "", err
+	}
+
+	wchan := strings.TrimSpace(string(data))
+	if wchan == "0" {
+		return "", nil
+	}
+	return wchan, nil
+}
+
+// KernelStackFrame is a single frame of the kernel stack of a process.
+type KernelStackFrame struct {
+	// Address of the frame. Kernels hide addresses from unprivileged
+	// readers and, since Linux 4.15, from all readers, reporting 0 instead.
+	Address uint64
+	// Name of the function.
+	Symbol string
+	// Offset of the return address in the function.
+	Offset uint64
+	// Size of the function.
+	Size uint64
+	// Module containing the function, empty for the core kernel.
+	Module string
+}
+
+// KernelStack returns the kernel stack of the process, read from
+// /proc/[pid]/stack, starting with the innermost frame. A PermissionError is
+// returned without CAP_SYS_ADMIN.
+func (p Proc) KernelStack() ([]KernelStackFrame, error) {
+	data, err := p.readPrivilegedFile("stack")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseKernelStack(data)
+}
+
+func parseKernelStack(data []byte) ([]KernelStackFrame, error) {
+	var (
+		frames []KernelStackFrame
+		s      = bufio.NewScanner(bytes.NewReader(data))
+	)
+
+	for s.Scan() {
+		// Lines look like "[<0>] nfs_wait_bit_killable+0x21/0x90 [nfs]".
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("invalid stack line: %q", s.Text())
+		}
+
+		var (
+			frame KernelStackFrame
+			err   error
+		)
+
+		addr := strings.TrimSuffix(strings.TrimPrefix(fields[0], "[<"), ">]")
+		if frame.Address, err = strconv.ParseUint(addr, 16, 64); err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (stack address): %s", fields[0], err)
+		}
+
+		plus := strings.LastIndex(fields[1], "+")
+		slash := strings.LastIndex(fields[1], "/")
+		if plus < 0 || slash < plus {
+			// Frames without symbol information only have the address.
+			frame.Symbol = fields[1]
+		} else {
+			frame.Symbol = fields[1][:plus]
+			if frame.Offset, err = strconv.ParseUint(fields[1][plus+1:slash], 0, 64); err != nil {
+				return nil, fmt.Errorf("couldn't parse %s (stack offset): %s", fields[1], err)
+			}
+			if frame.Size, err = strconv.ParseUint(fields[1][slash+1:], 0, 64); err != nil {
+				return nil, fmt.Errorf("couldn't parse %s (stack size): %s", fields[1], err)
+			}
+		}
+
+		if len(fields) == 3 {
+			frame.Module = strings.Trim(fields[2], "[]")
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, s.Err()
+}
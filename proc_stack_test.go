@@ -0,0 +1,69 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWchan(t *testing.T) {
+	for _, test := range []struct {
+		pid  int
+		want string
+	}{
+		{pid: 26231, want: "poll_schedule_timeout"},
+		{pid: 26232, want: ""},
+	} {
+		p, err := getProcFixtures(t).NewProc(test.pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := p.Wchan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.want != have {
+			t.Errorf("want wchan %q, have %q", test.want, have)
+		}
+	}
+}
+
+func TestKernelStack(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := p.KernelStack()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []KernelStackFrame{
+		{Symbol: "poll_schedule_timeout.constprop.0", Offset: 0x46, Size: 0x70},
+		{Symbol: "do_sys_poll", Offset: 0x3f2, Size: 0x5a0},
+		{Symbol: "nfs_wait_bit_killable", Offset: 0x21, Size: 0x90, Module: "nfs"},
+		{Address: 0xffffffffa1b2c3d4, Symbol: "__x64_sys_poll", Offset: 0x37, Size: 0x130},
+		{Symbol: "do_syscall_64", Offset: 0x5b, Size: 0x1d0},
+		{Symbol: "entry_SYSCALL_64_after_hwframe", Offset: 0x44, Size: 0xa9},
+	}
+	if !reflect.DeepEqual(want, frames) {
+		t.Errorf("want %+v, have %+v", want, frames)
+	}
+
+	if _, err := parseKernelStack([]byte("[<zz>] foo+0x1/0x2\n")); err == nil {
+		t.Error("want an error for an invalid address")
+	}
+}
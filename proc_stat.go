@@ -16,7 +16,7 @@ package procfs
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 
 	"github.com/prometheus/procfs/internal/fs"
@@ -100,32 +100,75 @@ type ProcStat struct {
 	VSize uint
 	// Resident set size in pages.
 	RSS int
+	// Soft limit on the resident set size in bytes.
+	RSSLimit uint64
+	// Address above which program text can run.
+	StartCode uint64
+	// Address below which program text can run.
+	EndCode uint64
+	// Address of the start (i.e., bottom) of the stack.
+	StartStack uint64
+	// Current value of the stack pointer.
+	KstkESP uint64
+	// Current value of the instruction pointer.
+	KstkEIP uint64
+	// Signal sent to the parent when the process dies.
+	ExitSignal int
+	// CPU number last executed on.
+	Processor int
+	// Real-time scheduling priority, a number in the range 1 to 99 for
+	// processes scheduled under a real-time policy, or 0.
+	RTPriority uint
+	// Scheduling policy, see the SCHED_* constants in linux/sched.h.
+	Policy uint
+	// Aggregated block I/O delays, measured in clock ticks.
+	DelayAcctBlkIOTicks uint64
+	// Time spent running a virtual CPU for a guest operating system,
+	// measured in clock ticks.
+	GuestTime uint
+	// Guest time of the process's children, measured in clock ticks.
+	CGuestTime int
+	// Address above which program initialized and uninitialized (BSS) data
+	// are placed.
+	StartData uint64
+	// Address below which program initialized and uninitialized (BSS) data
+	// are placed.
+	EndData uint64
+	// Address above which program heap can be expanded with brk(2).
+	StartBrk uint64
+	// Address above which program command-line arguments are placed.
+	ArgStart uint64
+	// Address below which program command-line arguments are placed.
+	ArgEnd uint64
+	// Address above which program environment is placed.
+	EnvStart uint64
+	// Address below which program environment is placed.
+	EnvEnd uint64
+	// The thread's exit status in the form reported by waitpid(2).
+	ExitCode int
 
 	proc fs.FS
 }
 
 // NewStat returns the current status information of the process.
 func (p Proc) NewStat() (ProcStat, error) {
-	f, err := os.Open(p.path("stat"))
-	if err != nil {
-		return ProcStat{}, err
-	}
-	defer f.Close()
-
-	data, err := ioutil.ReadAll(f)
+	data, err := p.readFile("stat")
 	if err != nil {
 		return ProcStat{}, err
 	}
 
 	var (
-		ignore int
+		ignore  int
+		ignoreU uint64
 
 		s = ProcStat{PID: p.PID, proc: p.fs}
 		l = bytes.Index(data, []byte("("))
+		// The comm may itself contain parentheses and spaces, so it ends at
+		// the last closing parenthesis.
 		r = bytes.LastIndex(data, []byte(")"))
 	)
 
-	if l < 0 || r < 0 {
+	if l < 0 || r < l {
 		return ProcStat{}, fmt.Errorf(
 			"unexpected format, couldn't extract comm: %s",
 			data,
@@ -133,8 +176,9 @@ func (p Proc) NewStat() (ProcStat, error) {
 	}
 
 	s.Comm = string(data[l+1 : r])
+	buf := bytes.NewBuffer(data[r+1:])
 	_, err = fmt.Fscan(
-		bytes.NewBuffer(data[r+2:]),
+		buf,
 		&s.State,
 		&s.PPID,
 		&s.PGRP,
@@ -162,6 +206,46 @@ func (p Proc) NewStat() (ProcStat, error) {
 		return ProcStat{}, err
 	}
 
+	// The remaining fields were added over time and are missing on older
+	// kernels. The obsolete signal bitmaps, the wchan placeholder and the
+	// unmaintained swap counters are skipped.
+	for _, v := range []interface{}{
+		&s.RSSLimit,
+		&s.StartCode,
+		&s.EndCode,
+		&s.StartStack,
+		&s.KstkESP,
+		&s.KstkEIP,
+		&ignoreU,
+		&ignoreU,
+		&ignoreU,
+		&ignoreU,
+		&ignoreU,
+		&ignoreU,
+		&ignoreU,
+		&s.ExitSignal,
+		&s.Processor,
+		&s.RTPriority,
+		&s.Policy,
+		&s.DelayAcctBlkIOTicks,
+		&s.GuestTime,
+		&s.CGuestTime,
+		&s.StartData,
+		&s.EndData,
+		&s.StartBrk,
+		&s.ArgStart,
+		&s.ArgEnd,
+		&s.EnvStart,
+		&s.EnvEnd,
+		&s.ExitCode,
+	} {
+		if _, err := fmt.Fscan(buf, v); err == io.EOF {
+			break
+		} else if err != nil {
+			return ProcStat{}, err
+		}
+	}
+
 	return s, nil
 }
 
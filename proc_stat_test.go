@@ -47,6 +47,57 @@ func TestProcStat(t *testing.T) {
 	}
 }
 
+func TestProcStatExtendedFields(t *testing.T) {
+	s, err := testProcStat(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		want uint64
+		have uint64
+	}{
+		{name: "rss limit", want: 18446744073709551615, have: s.RSSLimit},
+		{name: "start code", want: 4194304, have: s.StartCode},
+		{name: "end code", want: 6294284, have: s.EndCode},
+		{name: "start stack", want: 140736914091744, have: s.StartStack},
+		{name: "kstkesp", want: 140736914087944, have: s.KstkESP},
+		{name: "kstkeip", want: 139965136429984, have: s.KstkEIP},
+		{name: "exit signal", want: 17, have: uint64(s.ExitSignal)},
+		{name: "processor", want: 0, have: uint64(s.Processor)},
+		{name: "rt priority", want: 0, have: uint64(s.RTPriority)},
+		{name: "policy", want: 0, have: uint64(s.Policy)},
+		{name: "delayacct blkio ticks", want: 31, have: s.DelayAcctBlkIOTicks},
+		{name: "guest time", want: 0, have: uint64(s.GuestTime)},
+		{name: "cguest time", want: 0, have: uint64(s.CGuestTime)},
+		{name: "start data", want: 8391624, have: s.StartData},
+		{name: "end data", want: 8481048, have: s.EndData},
+		{name: "start brk", want: 16420864, have: s.StartBrk},
+		{name: "arg start", want: 140736914093252, have: s.ArgStart},
+		{name: "arg end", want: 140736914093279, have: s.ArgEnd},
+		{name: "env start", want: 140736914093279, have: s.EnvStart},
+		{name: "env end", want: 140736914096107, have: s.EnvEnd},
+		{name: "exit code", want: 0, have: uint64(s.ExitCode)},
+	} {
+		if test.want != test.have {
+			t.Errorf("want %s %d, have %d", test.name, test.want, test.have)
+		}
+	}
+
+	// The comm of 584 contains spaces and parentheses.
+	s, err = testProcStat(584)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 5, s.Processor; want != have {
+		t.Errorf("want processor %d, have %d", want, have)
+	}
+	if want, have := uint64(140736466518002), s.EnvEnd; want != have {
+		t.Errorf("want env end %d, have %d", want, have)
+	}
+}
+
 func TestProcStatComm(t *testing.T) {
 	s1, err := testProcStat(26231)
 	if err != nil {
@@ -15,10 +15,11 @@ package procfs
 
 import (
 	"bytes"
-	"io/ioutil"
-	"os"
+	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/prometheus/procfs/internal/util"
 )
 
 // ProcStat provides status information about the process,
@@ -28,6 +29,27 @@ type ProcStatus struct {
 	PID int
 	// The process name.
 	Name string
+	// Single character state of the process, e.g. R for running or S for
+	// sleeping.
+	State string
+	// The thread group ID.
+	TGID uint64
+	// Thread group IDs in each PID namespace the process is in, starting
+	// with the outermost one.
+	NStgids []uint64
+	// Process IDs in each PID namespace the process is in.
+	NSpids []uint64
+	// Process group IDs in each PID namespace the process is in.
+	NSpgids []uint64
+	// Session IDs in each PID namespace the process is in.
+	NSsids []uint64
+
+	// Real, effective, saved set and filesystem user IDs.
+	UIDs [4]uint64
+	// Real, effective, saved set and filesystem group IDs.
+	GIDs [4]uint64
+	// Supplementary group IDs.
+	Groups []uint64
 
 	// Peak virtual memory size.
 	VmPeak uint64
@@ -64,21 +86,56 @@ type ProcStatus struct {
 	// Size of hugetlb memory portions
 	HugetlbPages uint64
 
+	// Number of threads in the process.
+	Threads uint64
+
+	// Number of signals queued for the real user ID of the process.
+	SigQueued uint64
+	// Resource limit on the number of queued signals.
+	SigQueueLimit uint64
+	// Bitmask of signals pending for the thread.
+	SigPnd uint64
+	// Bitmask of signals pending for the process as a whole.
+	ShdPnd uint64
+	// Bitmask of blocked signals.
+	SigBlk uint64
+	// Bitmask of ignored signals.
+	SigIgn uint64
+	// Bitmask of caught signals.
+	SigCgt uint64
+
+	// Inheritable capabilities.
+	CapInh CapabilitySet
+	// Permitted capabilities.
+	CapPrm CapabilitySet
+	// Effective capabilities.
+	CapEff CapabilitySet
+	// Capability bounding set.
+	CapBnd CapabilitySet
+	// Ambient capabilities.
+	CapAmb CapabilitySet
+
+	// Seccomp mode of the process: 0 disabled, 1 strict or 2 filter.
+	Seccomp int
+	// Whether the no_new_privs bit is set.
+	NoNewPrivs bool
+
+	// CPUs the process may run on.
+	CpusAllowedList []uint64
+	// Memory nodes the process may allocate memory on.
+	MemsAllowedList []uint64
+
 	// Number of voluntary context switches.
 	VoluntaryCtxtSwitches uint64
 	// Number of involuntary context switches.
 	NonVoluntaryCtxtSwitches uint64
 }
 
-// NewStatus returns the current status information of the process.
+// NewStatus returns the current status information of the process. Fields
+// that are missing or have a format unknown to this package are left at their
+// zero value.
 func (p Proc) NewStatus() (ProcStatus, error) {
-	f, err := os.Open(p.path("status"))
-	if err != nil {
-		return ProcStatus{}, err
-	}
-	defer f.Close()
-
-	data, err := ioutil.ReadAll(f)
+	data, err := p.readFile("status")
 	if err != nil {
 		return ProcStatus{}, err
 	}
@@ -96,12 +153,10 @@ func (p Proc) NewStatus() (ProcStatus, error) {
 		// removes spaces
 		k := string(strings.TrimSpace(kv[0]))
 		v := string(strings.TrimSpace(kv[1]))
-		// removes "kB"
-		v = string(bytes.Trim([]byte(v), " kB"))
 
-		// value to int when possible
+		// value to int when possible, after removing "kB"
 		// we can skip error check here, 'cause vKBytes is not used when value is a string
-		vKBytes, _ := strconv.ParseUint(v, 10, 64)
+		vKBytes, _ := strconv.ParseUint(strings.TrimSuffix(v, " kB"), 10, 64)
 		// convert kB to B
 		vBytes := vKBytes * 1024
 
@@ -111,10 +166,32 @@ func (p Proc) NewStatus() (ProcStatus, error) {
 	return s, nil
 }
 
+// fillStatus sets the field of key k. Malformed values are skipped, leaving
+// the field at its zero value.
 func (s *ProcStatus) fillStatus(k string, vString string, vUint uint64, vUintBytes uint64) {
 	switch k {
 	case "Name":
 		s.Name = vString
+	case "State":
+		s.State = firstWord(vString)
+	case "Tgid":
+		s.TGID, _ = strconv.ParseUint(vString, 10, 64)
+	case "NStgid":
+		s.NStgids, _ = util.ParseUint64s(strings.Fields(vString))
+	case "NSpid":
+		s.NSpids, _ = util.ParseUint64s(strings.Fields(vString))
+	case "NSpgid":
+		s.NSpgids, _ = util.ParseUint64s(strings.Fields(vString))
+	case "NSsid":
+		s.NSsids, _ = util.ParseUint64s(strings.Fields(vString))
+	case "Uid":
+		s.UIDs, _ = parseStatusIDs(vString)
+	case "Gid":
+		s.GIDs, _ = parseStatusIDs(vString)
+	case "Groups":
+		if vString != "" {
+			s.Groups, _ = util.ParseUint64s(strings.Fields(vString))
+		}
 	case "VmPeak":
 		s.VmPeak = vUintBytes
 	case "VmSize":
@@ -149,6 +226,38 @@ func (s *ProcStatus) fillStatus(k string, vString string, vUint uint64, vUintByt
 		s.VmSwap = vUintBytes
 	case "HugetlbPages":
 		s.HugetlbPages = vUintBytes
+	case "Threads":
+		s.Threads = vUint
+	case "SigQ":
+		s.SigQueued, s.SigQueueLimit, _ = parseStatusSigQ(vString)
+	case "SigPnd":
+		s.SigPnd, _ = strconv.ParseUint(vString, 16, 64)
+	case "ShdPnd":
+		s.ShdPnd, _ = strconv.ParseUint(vString, 16, 64)
+	case "SigBlk":
+		s.SigBlk, _ = strconv.ParseUint(vString, 16, 64)
+	case "SigIgn":
+		s.SigIgn, _ = strconv.ParseUint(vString, 16, 64)
+	case "SigCgt":
+		s.SigCgt, _ = strconv.ParseUint(vString, 16, 64)
+	case "CapInh":
+		s.CapInh, _ = parseCapabilitySet(vString)
+	case "CapPrm":
+		s.CapPrm, _ = parseCapabilitySet(vString)
+	case "CapEff":
+		s.CapEff, _ = parseCapabilitySet(vString)
+	case "CapBnd":
+		s.CapBnd, _ = parseCapabilitySet(vString)
+	case "CapAmb":
+		s.CapAmb, _ = parseCapabilitySet(vString)
+	case "Seccomp":
+		s.Seccomp, _ = strconv.Atoi(vString)
+	case "NoNewPrivs":
+		s.NoNewPrivs = vString == "1"
+	case "Cpus_allowed_list":
+		s.CpusAllowedList, _ = parseIDList(vString)
+	case "Mems_allowed_list":
+		s.MemsAllowedList, _ = parseIDList(vString)
 	case "voluntary_ctxt_switches":
 		s.VoluntaryCtxtSwitches = vUint
 	case "nonvoluntary_ctxt_switches":
@@ -160,3 +269,92 @@ func (s *ProcStatus) fillStatus(k string, vString string, vUint uint64, vUintByt
 func (s ProcStatus) TotalCtxtSwitches() uint64 {
 	return s.VoluntaryCtxtSwitches + s.NonVoluntaryCtxtSwitches
 }
+
+func firstWord(s string) string {
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i]
+	}
+
+	return s
+}
+
+// parseStatusIDs parses the real, effective, saved set and filesystem IDs of
+// the Uid and Gid lines.
+func parseStatusIDs(s string) ([4]uint64, error) {
+	var ids [4]uint64
+
+	fields := strings.Fields(s)
+	if len(fields) != len(ids) {
+		return [4]uint64{}, fmt.Errorf("%d IDs were detected, but %d were expected", len(fields), len(ids))
+	}
+
+	for i, f := range fields {
+		id, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return [4]uint64{}, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// parseStatusSigQ parses a SigQ value of the form queued/limit.
+func parseStatusSigQ(s string) (uint64, uint64, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected queued/limit")
+	}
+
+	queued, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return queued, limit, nil
+}
+
+func parseCapabilitySet(s string) (CapabilitySet, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return CapabilitySet(v), nil
+}
+
+// parseIDList parses a list of IDs and ID ranges like 0-3,8,10-11 into the
+// individual IDs.
+func parseIDList(s string) ([]uint64, error) {
+	var ids []uint64
+	if s == "" {
+		return ids, nil
+	}
+
+	for _, r := range strings.Split(s, ",") {
+		bounds := strings.SplitN(r, "-", 2)
+		first, err := strconv.ParseUint(bounds[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.ParseUint(bounds[1], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		if last < first {
+			return nil, fmt.Errorf("invalid range %s", r)
+		}
+
+		for id := first; id <= last; id++ {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
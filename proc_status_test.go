@@ -14,6 +14,7 @@
 package procfs
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -74,3 +75,163 @@ func TestProcStatusName(t *testing.T) {
 		t.Errorf("want name %s, have %s", want, have)
 	}
 }
+
+func TestProcStatusIdentity(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := p.NewStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := "S", s.State; want != have {
+		t.Errorf("want state %s, have %s", want, have)
+	}
+	if want, have := uint64(1), s.TGID; want != have {
+		t.Errorf("want tgid %d, have %d", want, have)
+	}
+	if want, have := uint64(1), s.Threads; want != have {
+		t.Errorf("want threads %d, have %d", want, have)
+	}
+	if want, have := [4]uint64{1000, 1000, 1000, 0}, s.UIDs; want != have {
+		t.Errorf("want uids %v, have %v", want, have)
+	}
+	if want, have := [4]uint64{1001, 1001, 1001, 1001}, s.GIDs; want != have {
+		t.Errorf("want gids %v, have %v", want, have)
+	}
+	for _, test := range []struct {
+		name string
+		want []uint64
+		have []uint64
+	}{
+		{name: "Groups", want: []uint64{4, 24, 27}, have: s.Groups},
+		{name: "NStgids", want: []uint64{1}, have: s.NStgids},
+		{name: "NSpids", want: []uint64{1}, have: s.NSpids},
+		{name: "NSpgids", want: []uint64{1}, have: s.NSpgids},
+		{name: "NSsids", want: []uint64{1}, have: s.NSsids},
+		{name: "CpusAllowedList", want: []uint64{0, 1, 2, 3, 6}, have: s.CpusAllowedList},
+		{name: "MemsAllowedList", want: []uint64{0}, have: s.MemsAllowedList},
+	} {
+		if !reflect.DeepEqual(test.want, test.have) {
+			t.Errorf("want %s %v, have %v", test.name, test.want, test.have)
+		}
+	}
+}
+
+func TestProcStatusSignals(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := p.NewStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		want uint64
+		have uint64
+	}{
+		{name: "SigQueued", want: 8, have: s.SigQueued},
+		{name: "SigQueueLimit", want: 63965, have: s.SigQueueLimit},
+		{name: "SigPnd", want: 0, have: s.SigPnd},
+		{name: "ShdPnd", want: 0, have: s.ShdPnd},
+		{name: "SigBlk", want: 0x7be3c0fe28014a03, have: s.SigBlk},
+		{name: "SigIgn", want: 0x1000, have: s.SigIgn},
+		{name: "SigCgt", want: 0x1800004ec, have: s.SigCgt},
+	} {
+		if test.want != test.have {
+			t.Errorf("want %s %#x, have %#x", test.name, test.want, test.have)
+		}
+	}
+}
+
+func TestProcStatusSecurity(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := p.NewStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := []Capability{CapNetAdmin, CapNetRaw, CapSysAdmin}, s.CapEff.Capabilities(); !reflect.DeepEqual(want, have) {
+		t.Errorf("want effective capabilities %v, have %v", want, have)
+	}
+	if want, have := "cap_net_admin,cap_net_raw,cap_sys_admin", s.CapEff.String(); want != have {
+		t.Errorf("want effective capabilities %s, have %s", want, have)
+	}
+	if !s.CapBnd.Has(CapAuditRead) || s.CapBnd.Has(CapPerfmon) {
+		t.Errorf("unexpected bounding set %s", s.CapBnd)
+	}
+	if want, have := 38, len(s.CapPrm.Capabilities()); want != have {
+		t.Errorf("want %d permitted capabilities, have %d", want, have)
+	}
+	if s.CapInh != 0 || s.CapAmb != 0 {
+		t.Errorf("want empty inheritable and ambient sets, have %s and %s", s.CapInh, s.CapAmb)
+	}
+	if want, have := 2, s.Seccomp; want != have {
+		t.Errorf("want seccomp mode %d, have %d", want, have)
+	}
+	if !s.NoNewPrivs {
+		t.Error("want NoNewPrivs set")
+	}
+}
+
+func TestProcStatusMalformed(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := p.NewStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ProcStatus{PID: 26234, Name: "malformed", VmRSS: 4096}
+	if !reflect.DeepEqual(want, s) {
+		t.Errorf("want %+v, have %+v", want, s)
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want []uint64
+		err  bool
+	}{
+		{s: "", want: nil},
+		{s: "0", want: []uint64{0}},
+		{s: "0-2,5,7-8", want: []uint64{0, 1, 2, 5, 7, 8}},
+		{s: "3-1", err: true},
+		{s: "a-b", err: true},
+	} {
+		have, err := parseIDList(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: want an error, have none", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", test.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.want, have) {
+			t.Errorf("%q: want %v, have %v", test.s, test.want, have)
+		}
+	}
+}
+
+func TestCapabilityString(t *testing.T) {
+	if want, have := "cap_sys_admin", CapSysAdmin.String(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+	if want, have := "cap_63", Capability(63).String(); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProcSyscall models the content of /proc/[pid]/syscall, the system call the
+// process is blocked in.
+type ProcSyscall struct {
+	// Whether the process is running, in which case no other field is set.
+	Running bool
+	// Whether the process is blocked in a system call. If the process is
+	// blocked elsewhere, only SP and PC are set.
+	InSyscall bool
+	// Number of the system call, -1 if the process isn't in a system call.
+	Number int
+	// Arguments of the system call.
+	Args [6]uint64
+	// Stack pointer.
+	SP uint64
+	// Program counter.
+	PC uint64
+}
+
+// Syscall returns the system call the process is blocked in, read from
+// /proc/[pid]/syscall. A PermissionError is returned without ptrace access
+// to the process.
+func (p Proc) Syscall() (ProcSyscall, error) {
+	data, err := p.readPrivilegedFile("syscall")
+	if err != nil {
+		return ProcSyscall{}, err
+	}
+
+	return parseSyscall(strings.TrimSpace(string(data)))
+}
+
+func parseSyscall(s string) (ProcSyscall, error) {
+	if s == "running" {
+		return ProcSyscall{Running: true, Number: -1}, nil
+	}
+
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 3 && fields[0] == "-1":
+	case len(fields) == 9:
+	default:
+		return ProcSyscall{}, fmt.Errorf("invalid syscall line: %q", s)
+	}
+
+	nr, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return ProcSyscall{}, fmt.Errorf("couldn't parse %s (syscall number): %s", fields[0], err)
+	}
+
+	values := make([]uint64, len(fields)-1)
+	for i, f := range fields[1:] {
+		if values[i], err = strconv.ParseUint(f, 0, 64); err != nil {
+			return ProcSyscall{}, fmt.Errorf("couldn't parse %s (syscall): %s", f, err)
+		}
+	}
+
+	sc := ProcSyscall{
+		InSyscall: nr >= 0,
+		Number:    nr,
+		SP:        values[len(values)-2],
+		PC:        values[len(values)-1],
+	}
+	copy(sc.Args[:], values[:len(values)-2])
+
+	return sc, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"testing"
+)
+
+func TestProcSyscall(t *testing.T) {
+	for _, test := range []struct {
+		pid  int
+		want ProcSyscall
+	}{
+		{
+			pid: 26231,
+			want: ProcSyscall{
+				InSyscall: true,
+				Number:    7,
+				Args:      [6]uint64{0x55d4f5a0c2a0, 0x1, 0xffffffff, 0x8, 0x0, 0x7ffd3c1e3d40},
+				SP:        0x7ffd3c1e3c58,
+				PC:        0x7f1e2b4a5bff,
+			},
+		},
+		{
+			pid:  26232,
+			want: ProcSyscall{Running: true, Number: -1},
+		},
+		{
+			pid:  30001,
+			want: ProcSyscall{Number: -1, SP: 0x7ffc0a1b2c30, PC: 0x7f3d2e1f4a5b},
+		},
+	} {
+		p, err := getProcFixtures(t).NewProc(test.pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := p.Syscall()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.want != have {
+			t.Errorf("%d: want %+v, have %+v", test.pid, test.want, have)
+		}
+	}
+}
+
+func TestParseSyscallErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"7 0x1 0x2",
+		"-1 0x1",
+		"x 0x1 0x2 0x3 0x4 0x5 0x6 0x7 0x8",
+		"7 0x1 0x2 0x3 0x4 0x5 0x6 0x7 zz",
+	} {
+		if _, err := parseSyscall(s); err == nil {
+			t.Errorf("%q: want an error, have none", s)
+		}
+	}
+}
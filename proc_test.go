@@ -14,8 +14,10 @@
 package procfs
 
 import (
+	"os"
 	"reflect"
 	"sort"
+	"syscall"
 	"testing"
 )
 
@@ -223,6 +225,21 @@ func TestFileDescriptorsLen(t *testing.T) {
 	}
 }
 
+func TestPermissionError(t *testing.T) {
+	err := &PermissionError{
+		PID:  26231,
+		File: "stack",
+		Err:  &os.PathError{Op: "open", Path: "/proc/26231/stack", Err: syscall.EACCES},
+	}
+
+	if want, have := "no permission to access /proc/26231/stack: open /proc/26231/stack: permission denied", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if !os.IsPermission(err.Unwrap()) {
+		t.Error("want the underlying error to be a permission error")
+	}
+}
+
 type byUintptr []uintptr
 
 func (a byUintptr) Len() int           { return len(a) }
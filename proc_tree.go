@@ -0,0 +1,158 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"sort"
+)
+
+// ProcTree holds the parent/child relationships of a set of processes, built
+// from the PPID field of /proc/[pid]/stat. It is a snapshot and isn't updated
+// when processes start or exit.
+type ProcTree struct {
+	procs    map[int]Proc
+	stats    map[int]ProcStat
+	children map[int]Procs
+}
+
+// NewProcTree builds the process tree of all currently available processes.
+func NewProcTree() (*ProcTree, error) {
+	fs, err := NewFS(DefaultMountPoint)
+	if err != nil {
+		return nil, err
+	}
+	return fs.NewProcTree()
+}
+
+// NewProcTree builds the process tree of all currently available processes.
+func (fs FS) NewProcTree() (*ProcTree, error) {
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+	return procs.Tree()
+}
+
+// Tree builds the process tree of the processes in p. Processes that exit
+// before their stat file could be read are left out, and processes whose
+// parent isn't part of the tree become children of PID 0.
+func (p Procs) Tree() (*ProcTree, error) {
+	t := &ProcTree{
+		procs:    make(map[int]Proc, len(p)),
+		stats:    make(map[int]ProcStat, len(p)),
+		children: map[int]Procs{},
+	}
+
+	for _, proc := range p {
+		stat, err := proc.NewStat()
+		if isProcGone(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t.procs[proc.PID] = proc
+		t.stats[proc.PID] = stat
+		t.children[stat.PPID] = append(t.children[stat.PPID], proc)
+	}
+
+	// Attach orphans, whose parent exited while the tree was built or isn't
+	// in p, to the root so they can be reached from it.
+	for ppid, children := range t.children {
+		if _, ok := t.procs[ppid]; ok || ppid == 0 {
+			continue
+		}
+		t.children[0] = append(t.children[0], children...)
+		delete(t.children, ppid)
+	}
+
+	for _, children := range t.children {
+		sort.Sort(children)
+	}
+
+	return t, nil
+}
+
+// Stat returns the stat the tree was built from for the process pid, and
+// false if the process is not part of the tree.
+func (t *ProcTree) Stat(pid int) (ProcStat, bool) {
+	s, ok := t.stats[pid]
+	return s, ok
+}
+
+// Parent returns the parent of the process pid, and false if either the
+// process or its parent is not part of the tree.
+func (t *ProcTree) Parent(pid int) (Proc, bool) {
+	s, ok := t.stats[pid]
+	if !ok {
+		return Proc{}, false
+	}
+	p, ok := t.procs[s.PPID]
+	return p, ok
+}
+
+// Children returns the direct children of the process pid, sorted by PID.
+// The processes without a parent in the tree are the children of PID 0: init
+// and kthreadd, whose PPID is 0, as well as those whose parent exited while
+// the tree was built or wasn't among the processes it was built from.
+func (t *ProcTree) Children(pid int) Procs {
+	return append(Procs{}, t.children[pid]...)
+}
+
+// Descendants returns the children of the process pid, their children and so
+// on, sorted by PID.
+func (t *ProcTree) Descendants(pid int) Procs {
+	var (
+		descendants = Procs{}
+		seen        = map[int]bool{pid: true}
+		queue       = []int{pid}
+	)
+
+	for len(queue) > 0 {
+		for _, child := range t.children[queue[0]] {
+			// Guard against loops caused by PID reuse.
+			if seen[child.PID] {
+				continue
+			}
+			seen[child.PID] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child.PID)
+		}
+		queue = queue[1:]
+	}
+
+	sort.Sort(descendants)
+	return descendants
+}
+
+// Ancestors returns the parent of the process pid, its parent and so on up to
+// the root of the tree, starting with the parent.
+func (t *ProcTree) Ancestors(pid int) Procs {
+	var (
+		ancestors = Procs{}
+		seen      = map[int]bool{pid: true}
+	)
+
+	for {
+		parent, ok := t.Parent(pid)
+		// Guard against loops caused by PID reuse.
+		if !ok || seen[parent.PID] {
+			return ancestors
+		}
+		seen[parent.PID] = true
+		ancestors = append(ancestors, parent)
+		pid = parent.PID
+	}
+}
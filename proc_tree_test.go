@@ -0,0 +1,123 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProcTree(t *testing.T) {
+	tree, err := getProcFixtures(t).NewProcTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pids := func(procs Procs) []int {
+		pids := []int{}
+		for _, p := range procs {
+			pids = append(pids, p.PID)
+		}
+		return pids
+	}
+
+	for _, test := range []struct {
+		name string
+		want []int
+		have []int
+	}{
+		{name: "children of 26232", want: []int{30001}, have: pids(tree.Children(26232))},
+		{name: "children of 30001", want: []int{30002, 30003}, have: pids(tree.Children(30001))},
+		{name: "children of 30004", want: []int{}, have: pids(tree.Children(30004))},
+		{name: "descendants of 26232", want: []int{30001, 30002, 30003, 30004}, have: pids(tree.Descendants(26232))},
+		{name: "descendants of 30002", want: []int{30004}, have: pids(tree.Descendants(30002))},
+		{name: "ancestors of 30004", want: []int{30002, 30001, 26232}, have: pids(tree.Ancestors(30004))},
+		{name: "ancestors of 26231", want: []int{}, have: pids(tree.Ancestors(26231))},
+		// The parents of 26231 and 26232 are missing, as if they exited
+		// while the tree was built.
+		{name: "children of 0", want: []int{584, 26231, 26232}, have: pids(tree.Children(0))},
+		{name: "children of missing parent 5392", want: []int{}, have: pids(tree.Children(5392))},
+		{name: "descendants of 0", want: []int{584, 26231, 26232, 30001, 30002, 30003, 30004}, have: pids(tree.Descendants(0))},
+	} {
+		if !reflect.DeepEqual(test.want, test.have) {
+			t.Errorf("want %s %v, have %v", test.name, test.want, test.have)
+		}
+	}
+
+	parent, ok := tree.Parent(30003)
+	if !ok || parent.PID != 30001 {
+		t.Errorf("want parent 30001 of 30003, have %d (%t)", parent.PID, ok)
+	}
+
+	stat, ok := tree.Stat(30004)
+	if !ok {
+		t.Fatal("want stat of 30004")
+	}
+	if want, have := "logrotate", stat.Comm; want != have {
+		t.Errorf("want comm %s, have %s", want, have)
+	}
+
+	// 26233 has no stat file, as if it exited while the tree was built.
+	if _, ok := tree.Stat(26233); ok {
+		t.Error("want 26233 to be left out of the tree")
+	}
+}
+
+func TestProcTreeSubset(t *testing.T) {
+	fs := getProcFixtures(t)
+
+	var procs Procs
+	for _, pid := range []int{30002, 30004} {
+		p, err := fs.NewProc(pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		procs = append(procs, p)
+	}
+
+	tree, err := procs.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The parent 30001 of 30002 isn't in the set, so 30002 is a root.
+	if want, have := (Procs{procs[0]}), tree.Children(0); !reflect.DeepEqual(want, have) {
+		t.Errorf("want children of 0 %v, have %v", want, have)
+	}
+	if want, have := procs, tree.Descendants(0); !reflect.DeepEqual(want, have) {
+		t.Errorf("want descendants of 0 %v, have %v", want, have)
+	}
+	if _, ok := tree.Parent(30002); ok {
+		t.Error("want no parent of 30002")
+	}
+}
+
+func TestProcTreeLoop(t *testing.T) {
+	// Reused PIDs can make two processes each other's parent.
+	tree := &ProcTree{
+		procs: map[int]Proc{1: {PID: 1}, 2: {PID: 2}},
+		stats: map[int]ProcStat{1: {PID: 1, PPID: 2}, 2: {PID: 2, PPID: 1}},
+		children: map[int]Procs{
+			1: {{PID: 2}},
+			2: {{PID: 1}},
+		},
+	}
+
+	if want, have := 1, len(tree.Ancestors(1)); want != have {
+		t.Errorf("want %d ancestors, have %d", want, have)
+	}
+	if want, have := 1, len(tree.Descendants(1)); want != have {
+		t.Errorf("want %d descendants, have %d", want, have)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Swap represents an entry in /proc/swaps.
+type Swap struct {
+	// Path of the swap device or file.
+	Filename string
+	// Type of the swap area, partition or file.
+	Type string
+	// Size of the swap area in bytes.
+	Size uint64
+	// Used space of the swap area in bytes.
+	Used uint64
+	// Priority of the swap area. Areas with a higher priority are used first.
+	Priority int
+}
+
+// Swaps returns a slice of all configured swap areas read from /proc/swaps.
+func (fs FS) Swaps() ([]Swap, error) {
+	f, err := os.Open(fs.proc.Path("swaps"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseSwaps(f)
+}
+
+func parseSwaps(r io.Reader) ([]Swap, error) {
+	var (
+		swaps []Swap
+		s     = bufio.NewScanner(r)
+	)
+
+	for n := 0; s.Scan(); n++ {
+		// Skip the header line.
+		if n == 0 {
+			continue
+		}
+
+		fields := strings.Fields(s.Text())
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid swaps line, %d fields were detected, but 5 were expected: %q", len(fields), s.Text())
+		}
+
+		// Sizes are reported in KiB.
+		size, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (swap size): %s", fields[2], err)
+		}
+		used, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (swap used): %s", fields[3], err)
+		}
+		priority, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (swap priority): %s", fields[4], err)
+		}
+
+		swaps = append(swaps, Swap{
+			Filename: unescapeOctal(fields[0]),
+			Type:     fields[1],
+			Size:     size * 1024,
+			Used:     used * 1024,
+			Priority: priority,
+		})
+	}
+
+	return swaps, s.Err()
+}
+
+// unescapeOctal replaces the three digit octal escapes the kernel uses for
+// whitespace and backslashes in paths, e.g. \040 for a space.
+func unescapeOctal(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
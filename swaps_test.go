@@ -0,0 +1,78 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSwaps(t *testing.T) {
+	swaps, err := getProcFixtures(t).Swaps()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Swap{
+		{Filename: "/dev/dm-2", Type: "partition", Size: 131068 * 1024, Used: 176 * 1024, Priority: -2},
+		{Filename: "/var/swap file", Type: "file", Size: 1048572 * 1024, Used: 0, Priority: -3},
+	}
+	if !reflect.DeepEqual(want, swaps) {
+		t.Errorf("want %+v, have %+v", want, swaps)
+	}
+}
+
+func TestParseSwapsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{
+			name: "too few fields",
+			s:    "Filename Type Size Used Priority\n/dev/sda2 partition 1024 0\n",
+		},
+		{
+			name: "invalid size",
+			s:    "Filename Type Size Used Priority\n/dev/sda2 partition foo 0 -2\n",
+		},
+		{
+			name: "invalid priority",
+			s:    "Filename Type Size Used Priority\n/dev/sda2 partition 1024 0 bar\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSwaps(strings.NewReader(tt.s)); err == nil {
+				t.Error("want an error, have none")
+			}
+		})
+	}
+}
+
+func TestUnescapeOctal(t *testing.T) {
+	tests := map[string]string{
+		`/var/swap`:            "/var/swap",
+		`/var/my\040swap`:      "/var/my swap",
+		`/var/tab\011back\134`: "/var/tab\tback\\",
+		`/var/broken\04`:       `/var/broken\04`,
+	}
+
+	for in, want := range tests {
+		if have := unescapeOctal(in); want != have {
+			t.Errorf("unescapeOctal(%q): want %q, have %q", in, want, have)
+		}
+	}
+}
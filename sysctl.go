@@ -0,0 +1,185 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Sysctl reads the value of the kernel parameter name from /proc/sys. The
+// name is given in dotted notation, e.g. net.core.somaxconn. As with
+// sysctl(8), path components containing a dot, such as VLAN interface names,
+// are written with a slash instead: net.ipv4.conf.eth0/100.rp_filter.
+func (fs FS) Sysctl(name string) (string, error) {
+	path, err := fs.sysctlPath(name)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// SysctlInt reads the kernel parameter name, which must hold a single
+// integer.
+func (fs FS) SysctlInt(name string) (int, error) {
+	values, err := fs.SysctlInts(name)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) != 1 {
+		return 0, fmt.Errorf("sysctl %s holds %d values, expected 1", name, len(values))
+	}
+
+	return values[0], nil
+}
+
+// SysctlInts reads the kernel parameter name as a vector of whitespace
+// separated integers, like net.ipv4.ip_local_port_range or kernel.printk.
+func (fs FS) SysctlInts(name string) ([]int, error) {
+	value, err := fs.Sysctl(name)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(value)
+	values := make([]int, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s (sysctl %s): %s", f, name, err)
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// SysctlKeys returns the sorted names of all kernel parameters below the
+// subtree prefix, e.g. net.ipv4. An empty prefix lists all parameters.
+func (fs FS) SysctlKeys(prefix string) ([]string, error) {
+	root := fs.proc.Path("sys")
+	start, err := fs.sysctlPath(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	err = filepath.Walk(start, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return sysctlWalkErr(path, start, err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, sysctlName(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// sysctlWalkErr returns the error to stop a walk of the subtree start with, or
+// nil to skip the entry at path that failed with err. Entries vanishing during
+// the walk, like the directories of removed interfaces, and directories that
+// can't be listed are skipped, but a missing or unreadable start isn't.
+func sysctlWalkErr(path, start string, err error) error {
+	if path != start && (os.IsNotExist(err) || os.IsPermission(err)) {
+		return nil
+	}
+
+	return err
+}
+
+// SysctlSnapshot reads the values of all kernel parameters below the subtree
+// prefix, keyed by their dotted names. An empty prefix reads all parameters.
+// As with sysctl -a, parameters that can't be read are left out: those
+// needing more privileges, write only ones like vm.drop_caches, and those the
+// kernel refuses to read, like net.ipv6.conf.all.stable_secret before it is
+// set.
+func (fs FS) SysctlSnapshot(prefix string) (map[string]string, error) {
+	keys, err := fs.SysctlKeys(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := fs.Sysctl(key)
+		if err != nil {
+			continue
+		}
+		snapshot[key] = value
+	}
+
+	return snapshot, nil
+}
+
+// sysctlPath returns the path of the kernel parameter or subtree name below
+// /proc/sys. Names with empty, "." or ".." components are rejected, so that
+// they can't refer to files outside of /proc/sys.
+func (fs FS) sysctlPath(name string) (string, error) {
+	root := fs.proc.Path("sys")
+	if name == "" {
+		return root, nil
+	}
+
+	components := strings.Split(swapDotsAndSlashes(name), "/")
+	for _, c := range components {
+		if c == "" || c == "." || c == ".." {
+			return "", fmt.Errorf("invalid sysctl name %q", name)
+		}
+	}
+
+	path := filepath.Join(root, filepath.Join(components...))
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid sysctl name %q", name)
+	}
+
+	return path, nil
+}
+
+// sysctlName converts a path relative to /proc/sys into a dotted name.
+func sysctlName(path string) string {
+	return swapDotsAndSlashes(filepath.ToSlash(path))
+}
+
+func swapDotsAndSlashes(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.':
+			return '/'
+		case '/':
+			return '.'
+		}
+		return r
+	}, s)
+}
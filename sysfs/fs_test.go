@@ -16,7 +16,9 @@ package sysfs
 import "testing"
 
 const (
-	sysTestFixtures = "../fixtures/sys"
+	sysTestFixtures         = "../fixtures/sys"
+	sysBondingTestFixtures  = "../fixtures/sys-bonding"
+	sysWirelessTestFixtures = "../fixtures/sys-wireless"
 )
 
 func TestNewFS(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -59,6 +60,69 @@ type NetClassIface struct {
 	Speed            *int64 `fileName:"speed"`              // /sys/class/net/<iface>/speed
 	TxQueueLen       *int64 `fileName:"tx_queue_len"`       // /sys/class/net/<iface>/tx_queue_len
 	Type             *int64 `fileName:"type"`               // /sys/class/net/<iface>/type
+
+	Statistics NetClassStatistics `fileName:"statistics"`  // /sys/class/net/<iface>/statistics
+	RxQueues   []NetClassRxQueue  `fileName:"queues/rx-*"` // /sys/class/net/<iface>/queues/rx-<n>
+	TxQueues   []NetClassTxQueue  `fileName:"queues/tx-*"` // /sys/class/net/<iface>/queues/tx-<n>
+}
+
+// NetClassStatistics contains info from files in
+// /sys/class/net/<iface>/statistics.
+// https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-class-net-statistics
+type NetClassStatistics struct {
+	Collisions        *int64 `fileName:"collisions"`          // /sys/class/net/<iface>/statistics/collisions
+	Multicast         *int64 `fileName:"multicast"`           // /sys/class/net/<iface>/statistics/multicast
+	RxBytes           *int64 `fileName:"rx_bytes"`            // /sys/class/net/<iface>/statistics/rx_bytes
+	RxCompressed      *int64 `fileName:"rx_compressed"`       // /sys/class/net/<iface>/statistics/rx_compressed
+	RxCrcErrors       *int64 `fileName:"rx_crc_errors"`       // /sys/class/net/<iface>/statistics/rx_crc_errors
+	RxDropped         *int64 `fileName:"rx_dropped"`          // /sys/class/net/<iface>/statistics/rx_dropped
+	RxErrors          *int64 `fileName:"rx_errors"`           // /sys/class/net/<iface>/statistics/rx_errors
+	RxFifoErrors      *int64 `fileName:"rx_fifo_errors"`      // /sys/class/net/<iface>/statistics/rx_fifo_errors
+	RxFrameErrors     *int64 `fileName:"rx_frame_errors"`     // /sys/class/net/<iface>/statistics/rx_frame_errors
+	RxLengthErrors    *int64 `fileName:"rx_length_errors"`    // /sys/class/net/<iface>/statistics/rx_length_errors
+	RxMissedErrors    *int64 `fileName:"rx_missed_errors"`    // /sys/class/net/<iface>/statistics/rx_missed_errors
+	RxNoHandler       *int64 `fileName:"rx_nohandler"`        // /sys/class/net/<iface>/statistics/rx_nohandler
+	RxOverErrors      *int64 `fileName:"rx_over_errors"`      // /sys/class/net/<iface>/statistics/rx_over_errors
+	RxPackets         *int64 `fileName:"rx_packets"`          // /sys/class/net/<iface>/statistics/rx_packets
+	TxAbortedErrors   *int64 `fileName:"tx_aborted_errors"`   // /sys/class/net/<iface>/statistics/tx_aborted_errors
+	TxBytes           *int64 `fileName:"tx_bytes"`            // /sys/class/net/<iface>/statistics/tx_bytes
+	TxCarrierErrors   *int64 `fileName:"tx_carrier_errors"`   // /sys/class/net/<iface>/statistics/tx_carrier_errors
+	TxCompressed      *int64 `fileName:"tx_compressed"`       // /sys/class/net/<iface>/statistics/tx_compressed
+	TxDropped         *int64 `fileName:"tx_dropped"`          // /sys/class/net/<iface>/statistics/tx_dropped
+	TxErrors          *int64 `fileName:"tx_errors"`           // /sys/class/net/<iface>/statistics/tx_errors
+	TxFifoErrors      *int64 `fileName:"tx_fifo_errors"`      // /sys/class/net/<iface>/statistics/tx_fifo_errors
+	TxHeartbeatErrors *int64 `fileName:"tx_heartbeat_errors"` // /sys/class/net/<iface>/statistics/tx_heartbeat_errors
+	TxPackets         *int64 `fileName:"tx_packets"`          // /sys/class/net/<iface>/statistics/tx_packets
+	TxWindowErrors    *int64 `fileName:"tx_window_errors"`    // /sys/class/net/<iface>/statistics/tx_window_errors
+}
+
+// NetClassRxQueue contains info from files in
+// /sys/class/net/<iface>/queues/rx-<n> for a single receive queue.
+type NetClassRxQueue struct {
+	Name       string // Queue name, e.g. rx-0
+	RPSCPUs    string `fileName:"rps_cpus"`     // /sys/class/net/<iface>/queues/rx-<n>/rps_cpus
+	RPSFlowCnt *int64 `fileName:"rps_flow_cnt"` // /sys/class/net/<iface>/queues/rx-<n>/rps_flow_cnt
+}
+
+// NetClassTxQueue contains info from files in
+// /sys/class/net/<iface>/queues/tx-<n> for a single transmit queue.
+type NetClassTxQueue struct {
+	Name            string                  // Queue name, e.g. tx-0
+	XPSCPUs         string                  `fileName:"xps_cpus"`          // /sys/class/net/<iface>/queues/tx-<n>/xps_cpus
+	XPSRxQueues     string                  `fileName:"xps_rxqs"`          // /sys/class/net/<iface>/queues/tx-<n>/xps_rxqs
+	TxMaxRate       *int64                  `fileName:"tx_maxrate"`        // /sys/class/net/<iface>/queues/tx-<n>/tx_maxrate
+	TxTimeout       *int64                  `fileName:"tx_timeout"`        // /sys/class/net/<iface>/queues/tx-<n>/tx_timeout
+	ByteQueueLimits NetClassByteQueueLimits `fileName:"byte_queue_limits"` // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits
+}
+
+// NetClassByteQueueLimits contains info from files in
+// /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits.
+type NetClassByteQueueLimits struct {
+	HoldTime *int64 `fileName:"hold_time"` // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits/hold_time
+	Inflight *int64 `fileName:"inflight"`  // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits/inflight
+	Limit    *int64 `fileName:"limit"`     // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits/limit
+	LimitMax *int64 `fileName:"limit_max"` // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits/limit_max
+	LimitMin *int64 `fileName:"limit_min"` // /sys/class/net/<iface>/queues/tx-<n>/byte_queue_limits/limit_min
 }
 
 // NetClass is collection of info for every interface (iface) in /sys/class/net. The map keys
@@ -95,6 +159,42 @@ func (fs FS) NetClassDevices() ([]string, error) {
 	return res, nil
 }
 
+// NetClassIsWireless reports whether the given interface is a wireless
+// interface, i.e. whether /sys/class/net/<iface>/wireless exists.
+func (fs FS) NetClassIsWireless(iface string) (bool, error) {
+	info, err := os.Stat(fs.sys.Path(netclassPath, iface, "wireless"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return info.IsDir(), nil
+}
+
+// NetClassWirelessDevices scans /sys/class/net for wireless devices and
+// returns them as a list of names.
+func (fs FS) NetClassWirelessDevices() ([]string, error) {
+	devices, err := fs.NetClassDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, device := range devices {
+		wireless, err := fs.NetClassIsWireless(device)
+		if err != nil {
+			return nil, err
+		}
+		if wireless {
+			res = append(res, device)
+		}
+	}
+
+	return res, nil
+}
+
 // NewNetClass returns info for all net interfaces (iface) read from /sys/class/net/<iface>.
 func (fs FS) NewNetClass() (NetClass, error) {
 	devices, err := fs.NetClassDevices()
@@ -119,25 +219,53 @@ func (fs FS) NewNetClass() (NetClass, error) {
 // directory and gets their contents.
 func (nc NetClass) parseNetClassIface(devicePath string) (*NetClassIface, error) {
 	interfaceClass := NetClassIface{}
-	interfaceElem := reflect.ValueOf(&interfaceClass).Elem()
-	interfaceType := reflect.TypeOf(interfaceClass)
+	if err := parseNetClassAttributes(devicePath, reflect.ValueOf(&interfaceClass).Elem()); err != nil {
+		return nil, err
+	}
+
+	return &interfaceClass, nil
+}
+
+// parseNetClassAttributes fills the fields of the struct value elem with the
+// contents of the files in dir named by their fileName tags. Struct fields
+// are read from the subdirectory named by their tag, slice fields from all
+// subdirectories matching the glob pattern in their tag. The Name field is
+// left to the caller.
+func parseNetClassAttributes(dir string, elem reflect.Value) error {
+	elemType := elem.Type()
 
-	//start from 1 - skip the Name field
-	for i := 1; i < interfaceElem.NumField(); i++ {
-		fieldType := interfaceType.Field(i)
-		fieldValue := interfaceElem.Field(i)
+	for i := 0; i < elem.NumField(); i++ {
+		fieldType := elemType.Field(i)
+		fieldValue := elem.Field(i)
 
-		if fieldType.Tag.Get("fileName") == "" {
+		fileName := fieldType.Tag.Get("fileName")
+		if fileName == "" {
+			if fieldType.Name == "Name" {
+				continue
+			}
 			panic(fmt.Errorf("field %s does not have a filename tag", fieldType.Name))
 		}
 
-		value, err := util.SysReadFile(devicePath + "/" + fieldType.Tag.Get("fileName"))
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if err := parseNetClassAttributes(filepath.Join(dir, fileName), fieldValue); err != nil {
+				return err
+			}
+			continue
+		case reflect.Slice:
+			if err := parseNetClassAttributeDirs(filepath.Join(dir, fileName), fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := util.SysReadFile(filepath.Join(dir, fileName))
 
 		if err != nil {
 			if os.IsNotExist(err) || err.Error() == "operation not supported" || err.Error() == "invalid argument" {
 				continue
 			}
-			return nil, fmt.Errorf("could not access file %s: %s", fieldType.Tag.Get("fileName"), err)
+			return fmt.Errorf("could not access file %s: %s", fileName, err)
 		}
 
 		switch fieldValue.Kind() {
@@ -151,22 +279,56 @@ func (nc NetClass) parseNetClassIface(devicePath string) (*NetClassIface, error)
 				if strings.HasPrefix(value, "0x") {
 					intValue, err = strconv.ParseInt(value[2:], 16, 64)
 					if err != nil {
-						return nil, fmt.Errorf("expected hex value for %s, got: %s", fieldType.Name, value)
+						return fmt.Errorf("expected hex value for %s, got: %s", fieldType.Name, value)
 					}
 				} else {
 					intValue, err = strconv.ParseInt(value, 10, 64)
 					if err != nil {
-						return nil, fmt.Errorf("expected Uint64 value for %s, got: %s", fieldType.Name, value)
+						return fmt.Errorf("expected Uint64 value for %s, got: %s", fieldType.Name, value)
 					}
 				}
 				fieldValue.Set(reflect.ValueOf(&intValue))
 			default:
-				return nil, fmt.Errorf("unhandled pointer type %q", fieldValue.Type())
+				return fmt.Errorf("unhandled pointer type %q", fieldValue.Type())
 			}
 		default:
-			return nil, fmt.Errorf("unhandled type %q", fieldValue.Kind())
+			return fmt.Errorf("unhandled type %q", fieldValue.Kind())
 		}
 	}
 
-	return &interfaceClass, nil
+	return nil
+}
+
+// parseNetClassAttributeDirs fills the slice value field with one element per
+// directory matching pattern, e.g. one per queues/rx-<n> directory. The Name
+// field of each element is set to the directory name.
+func parseNetClassAttributeDirs(pattern string, field reflect.Value) error {
+	dirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	// Sort rx-2 before rx-10.
+	sort.Slice(dirs, func(i, j int) bool {
+		if len(dirs[i]) != len(dirs[j]) {
+			return len(dirs[i]) < len(dirs[j])
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	elems := reflect.MakeSlice(field.Type(), 0, len(dirs))
+	for _, dir := range dirs {
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := parseNetClassAttributes(dir, elem); err != nil {
+			return err
+		}
+		elem.FieldByName("Name").SetString(filepath.Base(dir))
+		elems = reflect.Append(elems, elem)
+	}
+	field.Set(elems)
+
+	return nil
 }
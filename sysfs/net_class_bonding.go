@@ -0,0 +1,162 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/procfs/internal/util"
+)
+
+// NetClassBonding contains info from files in /sys/class/net/<bond>/bonding
+// for a single bonding interface.
+// https://www.kernel.org/doc/Documentation/networking/bonding.txt
+type NetClassBonding struct {
+	Name           string   // Interface name
+	Mode           string   // Bonding mode, e.g. active-backup or 802.3ad.
+	Slaves         []string // Names of the slave interfaces.
+	ActiveSlave    string   // Optional: currently active slave.
+	Primary        string   // Optional: primary slave.
+	MIIStatus      string   // Link status of the bond, up or down.
+	MIIMon         *uint64  // Optional: MII link monitoring interval in milliseconds.
+	UpDelay        *uint64  // Optional: milliseconds before enabling a recovered slave.
+	DownDelay      *uint64  // Optional: milliseconds before disabling a failed slave.
+	MinLinks       *uint64  // Optional: minimum number of slaves with link up.
+	XmitHashPolicy string   // Optional: transmit hash policy, e.g. layer3+4.
+	LACPRate       string   // Optional: LACP rate, slow or fast.
+	ADAggregator   *uint64  // Optional: ID of the active aggregator (802.3ad only).
+	ADNumPorts     *uint64  // Optional: number of ports of the active aggregator (802.3ad only).
+	ADActorKey     *uint64  // Optional: LACP key of the local system (802.3ad only).
+	ADPartnerKey   *uint64  // Optional: LACP key of the link partner (802.3ad only).
+	ADPartnerMAC   string   // Optional: MAC address of the link partner (802.3ad only).
+}
+
+// NetClassBondingMasters returns the names of all bonding interfaces read from
+// /sys/class/net/bonding_masters.
+func (fs FS) NetClassBondingMasters() ([]string, error) {
+	masters, err := util.SysReadFile(fs.sys.Path(netclassPath, "bonding_masters"))
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(masters), nil
+}
+
+// NetClassBonding returns info for the given bonding interface read from
+// /sys/class/net/<bond>/bonding.
+func (fs FS) NetClassBonding(bond string) (NetClassBonding, error) {
+	path := fs.sys.Path(netclassPath, bond, "bonding")
+
+	b, err := parseNetClassBonding(path)
+	if err != nil {
+		return NetClassBonding{}, err
+	}
+	b.Name = bond
+
+	return b, nil
+}
+
+func parseNetClassBonding(path string) (NetClassBonding, error) {
+	var b NetClassBonding
+
+	// Required attributes.
+	mode, err := util.SysReadFile(filepath.Join(path, "mode"))
+	if err != nil {
+		return NetClassBonding{}, err
+	}
+	// Values like "802.3ad 4" hold both the name and the number of the mode.
+	b.Mode = firstField(mode)
+	slaves, err := util.SysReadFile(filepath.Join(path, "slaves"))
+	if err != nil {
+		return NetClassBonding{}, err
+	}
+	b.Slaves = strings.Fields(slaves)
+	if b.MIIStatus, err = util.SysReadFile(filepath.Join(path, "mii_status")); err != nil {
+		return NetClassBonding{}, err
+	}
+
+	// Optional attributes.
+	for _, attr := range []struct {
+		file  string
+		value *string
+	}{
+		{file: "active_slave", value: &b.ActiveSlave},
+		{file: "primary", value: &b.Primary},
+		{file: "xmit_hash_policy", value: &b.XmitHashPolicy},
+		{file: "lacp_rate", value: &b.LACPRate},
+		{file: "ad_partner_mac", value: &b.ADPartnerMAC},
+	} {
+		v, err := readOptionalAttribute(filepath.Join(path, attr.file))
+		if err != nil {
+			return NetClassBonding{}, err
+		}
+		*attr.value = firstField(v)
+	}
+
+	for _, attr := range []struct {
+		file  string
+		value **uint64
+	}{
+		{file: "miimon", value: &b.MIIMon},
+		{file: "updelay", value: &b.UpDelay},
+		{file: "downdelay", value: &b.DownDelay},
+		{file: "min_links", value: &b.MinLinks},
+		{file: "ad_aggregator", value: &b.ADAggregator},
+		{file: "ad_num_ports", value: &b.ADNumPorts},
+		{file: "ad_actor_key", value: &b.ADActorKey},
+		{file: "ad_partner_key", value: &b.ADPartnerKey},
+	} {
+		v, err := readOptionalAttribute(filepath.Join(path, attr.file))
+		if err != nil {
+			return NetClassBonding{}, err
+		}
+		if v == "" {
+			continue
+		}
+		u, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return NetClassBonding{}, fmt.Errorf("expected Uint64 value for %s, got: %s", attr.file, v)
+		}
+		*attr.value = &u
+	}
+
+	return b, nil
+}
+
+// readOptionalAttribute reads a bonding attribute that may be missing,
+// unreadable without privileges, or empty when it doesn't apply to the
+// bonding mode. The empty string is returned in all of these cases.
+func readOptionalAttribute(path string) (string, error) {
+	v, err := util.SysReadFile(path)
+	if os.IsNotExist(err) || os.IsPermission(err) {
+		return "", nil
+	}
+
+	return v, err
+}
+
+func firstField(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
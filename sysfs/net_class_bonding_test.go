@@ -0,0 +1,97 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sysfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNetClassBondingMasters(t *testing.T) {
+	fs, err := NewFS(sysBondingTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	masters, err := fs.NetClassBondingMasters()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"bond0", "bond1"}; !reflect.DeepEqual(want, masters) {
+		t.Errorf("Result not correct: want %v, have %v", want, masters)
+	}
+}
+
+func TestNetClassBonding(t *testing.T) {
+	fs, err := NewFS(sysBondingTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		miimon       uint64 = 100
+		delay        uint64 = 200
+		noDelay      uint64
+		minLinks     uint64
+		adAggregator uint64 = 1
+		adNumPorts   uint64 = 2
+		adActorKey   uint64 = 15
+		adPartnerKey uint64 = 32773
+	)
+
+	for _, want := range []NetClassBonding{
+		{
+			Name:           "bond0",
+			Mode:           "802.3ad",
+			Slaves:         []string{"eth4", "eth5"},
+			MIIStatus:      "up",
+			MIIMon:         &miimon,
+			UpDelay:        &delay,
+			DownDelay:      &delay,
+			MinLinks:       &minLinks,
+			XmitHashPolicy: "layer3+4",
+			LACPRate:       "fast",
+			ADAggregator:   &adAggregator,
+			ADNumPorts:     &adNumPorts,
+			ADActorKey:     &adActorKey,
+			ADPartnerKey:   &adPartnerKey,
+			ADPartnerMAC:   "00:1c:73:aa:bb:cc",
+		},
+		{
+			Name:           "bond1",
+			Mode:           "active-backup",
+			Slaves:         []string{"eth6", "eth7"},
+			ActiveSlave:    "eth7",
+			MIIStatus:      "up",
+			MIIMon:         &miimon,
+			UpDelay:        &noDelay,
+			DownDelay:      &noDelay,
+			MinLinks:       &minLinks,
+			XmitHashPolicy: "layer2",
+			LACPRate:       "slow",
+		},
+	} {
+		bonding, err := fs.NetClassBonding(want.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(want, bonding) {
+			t.Errorf("Result not correct: want %+v, have %+v", want, bonding)
+		}
+	}
+}
@@ -39,6 +39,30 @@ func TestNewNetClassDevices(t *testing.T) {
 	}
 }
 
+func TestNetClassWirelessDevices(t *testing.T) {
+	fs, err := NewFS(sysWirelessTestFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices, err := fs.NetClassWirelessDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"wlan0"}; !reflect.DeepEqual(want, devices) {
+		t.Errorf("Unexpected wireless devices, want %v, have %v", want, devices)
+	}
+
+	wireless, err := fs.NetClassIsWireless("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wireless {
+		t.Error("want eth0 not to be a wireless device")
+	}
+}
+
 func TestNewNetClass(t *testing.T) {
 	fs, err := NewFS(sysTestFixtures)
 	if err != nil {
@@ -69,6 +93,21 @@ func TestNewNetClass(t *testing.T) {
 		speed            int64 = 1000
 		txQueueLen       int64 = 1000
 		netType          int64 = 1
+
+		zero            int64
+		multicast       int64 = 1024
+		rxBytes         int64 = 874354587
+		rxErrors        int64 = 15
+		rxMissedErrors  int64 = 12
+		rxOverErrors    int64 = 3
+		rxPackets       int64 = 1036395
+		txBytes         int64 = 563352563
+		txCarrierErrors int64 = 7
+		txPackets       int64 = 732147
+		rpsFlowCnt      int64 = 2048
+		bqlHoldTime     int64 = 1000
+		bqlLimit        int64 = 9084
+		bqlLimitMax     int64 = 1879048192
 	)
 
 	netClass := NetClass{
@@ -100,6 +139,52 @@ func TestNewNetClass(t *testing.T) {
 			Speed:            &speed,
 			TxQueueLen:       &txQueueLen,
 			Type:             &netType,
+			Statistics: NetClassStatistics{
+				Collisions:        &zero,
+				Multicast:         &multicast,
+				RxBytes:           &rxBytes,
+				RxCompressed:      &zero,
+				RxCrcErrors:       &zero,
+				RxDropped:         &zero,
+				RxErrors:          &rxErrors,
+				RxFifoErrors:      &zero,
+				RxFrameErrors:     &zero,
+				RxLengthErrors:    &zero,
+				RxMissedErrors:    &rxMissedErrors,
+				RxNoHandler:       &zero,
+				RxOverErrors:      &rxOverErrors,
+				RxPackets:         &rxPackets,
+				TxAbortedErrors:   &zero,
+				TxBytes:           &txBytes,
+				TxCarrierErrors:   &txCarrierErrors,
+				TxCompressed:      &zero,
+				TxDropped:         &zero,
+				TxErrors:          &txCarrierErrors,
+				TxFifoErrors:      &zero,
+				TxHeartbeatErrors: &zero,
+				TxPackets:         &txPackets,
+				TxWindowErrors:    &zero,
+			},
+			RxQueues: []NetClassRxQueue{
+				{Name: "rx-0", RPSCPUs: "00000000,0000000f", RPSFlowCnt: &zero},
+				{Name: "rx-1", RPSCPUs: "00000000,000000f0", RPSFlowCnt: &rpsFlowCnt},
+			},
+			TxQueues: []NetClassTxQueue{
+				{
+					Name:        "tx-0",
+					XPSCPUs:     "00000000,000000ff",
+					XPSRxQueues: "0",
+					TxMaxRate:   &zero,
+					TxTimeout:   &zero,
+					ByteQueueLimits: NetClassByteQueueLimits{
+						HoldTime: &bqlHoldTime,
+						Inflight: &zero,
+						Limit:    &bqlLimit,
+						LimitMax: &bqlLimitMax,
+						LimitMin: &zero,
+					},
+				},
+			},
 		},
 	}
 
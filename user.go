@@ -0,0 +1,157 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UserResolver resolves user and group IDs, e.g. the UIDs and GIDs of
+// ProcStatus, to names. It reads etc/passwd and etc/group below a root
+// directory, so that the names of the host can be resolved from a container
+// with the host root mounted at e.g. /host. Unlike os/user, it doesn't need
+// cgo and ignores NSS sources like LDAP.
+//
+// The files are parsed on first use and again only after they changed. A
+// UserResolver is safe for concurrent use.
+type UserResolver struct {
+	users  idNameFile
+	groups idNameFile
+}
+
+// NewUserResolver returns a UserResolver reading the files below root, which
+// is / for the system the process runs on. The root is independent of the
+// mount point of the proc filesystem, see FS.NewUserResolver for a
+// UserResolver matching an FS.
+func NewUserResolver(root string) *UserResolver {
+	return &UserResolver{
+		users:  idNameFile{path: filepath.Join(root, "etc/passwd")},
+		groups: idNameFile{path: filepath.Join(root, "etc/group")},
+	}
+}
+
+// NewUserResolver returns a UserResolver reading the files below the parent
+// directory of the mount point of the proc filesystem, e.g. below /host for a
+// host proc filesystem mounted at /host/proc.
+func (fs FS) NewUserResolver() *UserResolver {
+	return NewUserResolver(filepath.Dir(fs.proc.Path()))
+}
+
+// UserName returns the name of the user uid. The decimal uid is returned if
+// the user has no entry in etc/passwd, or if etc/passwd doesn't exist.
+func (r *UserResolver) UserName(uid uint64) (string, error) {
+	return r.users.name(uid)
+}
+
+// GroupName returns the name of the group gid. The decimal gid is returned if
+// the group has no entry in etc/group, or if etc/group doesn't exist.
+func (r *UserResolver) GroupName(gid uint64) (string, error) {
+	return r.groups.name(gid)
+}
+
+// idNameFile caches the ID to name mapping of a file in the format of
+// /etc/passwd or /etc/group, with the name in the first field and the ID in
+// the third.
+type idNameFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	names   map[uint64]string
+}
+
+func (f *idNameFile) name(id uint64) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.refresh(); err != nil {
+		return "", err
+	}
+
+	if name, ok := f.names[id]; ok {
+		return name, nil
+	}
+	return strconv.FormatUint(id, 10), nil
+}
+
+// refresh parses the file if it changed since it was last parsed.
+func (f *idNameFile) refresh() error {
+	info, err := os.Stat(f.path)
+	if os.IsNotExist(err) {
+		// Without the file, no ID has a name. It is parsed once it
+		// appears.
+		f.names, f.modTime, f.size = map[uint64]string{}, time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if f.names != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return nil
+	}
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	names, err := parseIDNames(file)
+	if err != nil {
+		return err
+	}
+
+	f.names, f.modTime, f.size = names, info.ModTime(), info.Size()
+	return nil
+}
+
+func parseIDNames(r io.Reader) (map[uint64]string, error) {
+	var (
+		names = map[uint64]string{}
+		s     = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Skip malformed lines and NIS compat entries like "+::::::", as
+		// the C library does.
+		fields := strings.Split(line, ":")
+		if len(fields) < 3 || fields[0] == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		// The first entry of an ID wins.
+		if _, ok := names[id]; !ok {
+			names[id] = fields[0]
+		}
+	}
+
+	return names, s.Err()
+}
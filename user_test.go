@@ -0,0 +1,118 @@
+// Copyright 2019 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUserResolver(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26231)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := p.NewStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := getProcFixtures(t).NewUserResolver()
+
+	user, err := r.UserName(s.UIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "prometheus", user; want != have {
+		t.Errorf("want user %s, have %s", want, have)
+	}
+
+	group, err := r.GroupName(s.GIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "prometheus", group; want != have {
+		t.Errorf("want group %s, have %s", want, have)
+	}
+
+	for _, test := range []struct {
+		name   string
+		lookup func(uint64) (string, error)
+		id     uint64
+		want   string
+	}{
+		{name: "first entry wins", lookup: r.UserName, id: 0, want: "root"},
+		{name: "user", lookup: r.UserName, id: 33, want: "www-data"},
+		{name: "unknown user", lookup: r.UserName, id: 4242, want: "4242"},
+		{name: "supplementary group", lookup: r.GroupName, id: 27, want: "sudo"},
+		{name: "unknown group", lookup: r.GroupName, id: 4242, want: "4242"},
+	} {
+		have, err := test.lookup(test.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.want != have {
+			t.Errorf("%s: want %s, have %s", test.name, test.want, have)
+		}
+	}
+
+	missing := NewUserResolver("fixtures/nonexistent")
+	if name, err := missing.UserName(0); err != nil || name != "0" {
+		t.Errorf("want the decimal ID without etc/passwd, have %s (%v)", name, err)
+	}
+	if name, err := missing.GroupName(0); err != nil || name != "0" {
+		t.Errorf("want the decimal ID without etc/group, have %s (%v)", name, err)
+	}
+}
+
+func TestUserResolverReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "procfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewUserResolver(dir)
+	if name, err := r.UserName(1000); err != nil || name != "1000" {
+		t.Fatalf("want 1000 without etc/passwd, have %s (%v)", name, err)
+	}
+
+	passwd := filepath.Join(dir, "etc/passwd")
+	if err := ioutil.WriteFile(passwd, []byte("alice:x:1000:1000::/:/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if name, err := r.UserName(1000); err != nil || name != "alice" {
+		t.Fatalf("want alice, have %s (%v)", name, err)
+	}
+
+	if err := ioutil.WriteFile(passwd, []byte("bob:x:1000:1000::/:/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change is noticed on filesystems with coarse timestamps.
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(passwd, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, err := r.UserName(1000); err != nil || name != "bob" {
+		t.Errorf("want bob after the file changed, have %s (%v)", name, err)
+	}
+}
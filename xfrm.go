@@ -97,7 +97,18 @@ func NewXfrmStat() (XfrmStat, error) {
 
 // NewXfrmStat reads the xfrm_stat statistics from the 'proc' filesystem.
 func (fs FS) NewXfrmStat() (XfrmStat, error) {
-	file, err := os.Open(fs.proc.Path("net/xfrm_stat"))
+	return newXfrmStat(fs.proc.Path("net/xfrm_stat"))
+}
+
+// NewXfrmStat reads the xfrm_stat statistics of the network namespace of the
+// process from /proc/[pid]/net/xfrm_stat.
+func (p Proc) NewXfrmStat() (XfrmStat, error) {
+	return newXfrmStat(p.path("net/xfrm_stat"))
+}
+
+// newXfrmStat creates a new XfrmStat from the contents of the given file.
+func newXfrmStat(path string) (XfrmStat, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return XfrmStat{}, err
 	}
@@ -185,3 +196,60 @@ func (fs FS) NewXfrmStat() (XfrmStat, error) {
 
 	return x, s.Err()
 }
+
+// xfrmIfacePrefixes are the name prefixes of the interfaces considered to
+// carry IPsec traffic: the names the kernel gives xfrm and VTI interfaces
+// created without an explicit name (xfrm%d, vti%d and vti6%d), and the
+// ip_vti0 and ip6_vti0 fallback devices.
+var xfrmIfacePrefixes = []string{"xfrm", "vti", "ip_vti", "ip6_vti"}
+
+// XfrmOverview combines the IPsec error counters of a network namespace with
+// the statistics of its xfrm and VTI interfaces.
+type XfrmOverview struct {
+	// Namespace-wide xfrm error counters.
+	Stat XfrmStat
+	// Per-interface statistics of the xfrm and VTI interfaces, keyed by
+	// interface name. Interfaces are selected by their name prefix (xfrm,
+	// vti, ip_vti and ip6_vti), so renamed interfaces are missed.
+	Interfaces NetDev
+}
+
+// NewXfrmOverview reads the IPsec overview from the 'proc' filesystem.
+func (fs FS) NewXfrmOverview() (XfrmOverview, error) {
+	return newXfrmOverview(fs.NewXfrmStat, fs.NewNetDev)
+}
+
+// NewXfrmOverview reads the IPsec overview of the network namespace of the
+// process.
+func (p Proc) NewXfrmOverview() (XfrmOverview, error) {
+	return newXfrmOverview(p.NewXfrmStat, p.NewNetDev)
+}
+
+func newXfrmOverview(stat func() (XfrmStat, error), netDev func() (NetDev, error)) (XfrmOverview, error) {
+	x, err := stat()
+	if err != nil {
+		return XfrmOverview{}, err
+	}
+	nd, err := netDev()
+	if err != nil {
+		return XfrmOverview{}, err
+	}
+
+	o := XfrmOverview{Stat: x, Interfaces: NetDev{}}
+	for name, line := range nd {
+		if isXfrmIface(name) {
+			o.Interfaces[name] = line
+		}
+	}
+
+	return o, nil
+}
+
+func isXfrmIface(name string) bool {
+	for _, prefix := range xfrmIfacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -64,3 +64,57 @@ func TestXfrmStats(t *testing.T) {
 		}
 	}
 }
+
+func TestProcXfrmStats(t *testing.T) {
+	p, err := getProcFixtures(t).NewProc(26232)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xfrmStats, err := p.NewXfrmStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := (XfrmStat{XfrmInError: 7, XfrmInNoStates: 2}), xfrmStats; want != have {
+		t.Errorf("want %+v, have %+v", want, have)
+	}
+}
+
+func TestXfrmOverview(t *testing.T) {
+	o, err := getProcFixtures(t).NewXfrmOverview()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 1, o.Stat.XfrmInError; want != have {
+		t.Errorf("want XfrmInError %d, have %d", want, have)
+	}
+	if want, have := 0, len(o.Interfaces); want != have {
+		t.Errorf("want %d xfrm interfaces, have %d", want, have)
+	}
+
+	p, err := getProcFixtures(t).NewProc(26232)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err = p.NewXfrmOverview()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 7, o.Stat.XfrmInError; want != have {
+		t.Errorf("want XfrmInError %d, have %d", want, have)
+	}
+	if want, have := 1, len(o.Interfaces); want != have {
+		t.Fatalf("want %d xfrm interfaces, have %d", want, have)
+	}
+	xfrm0 := o.Interfaces["xfrm0"]
+	if want, have := uint64(3), xfrm0.RxErrors; want != have {
+		t.Errorf("want xfrm0 rx errors %d, have %d", want, have)
+	}
+	if want, have := uint64(5), xfrm0.TxErrors; want != have {
+		t.Errorf("want xfrm0 tx errors %d, have %d", want, have)
+	}
+}